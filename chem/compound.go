@@ -0,0 +1,30 @@
+package chem
+
+import "strings"
+
+// Compounds maps the common names of frequently used compounds to their formulas,
+// names are matched case-insensitively.
+var Compounds = map[string]string{
+	"water":     "H2O",
+	"glucose":   "C6H12O6",
+	"sucrose":   "C12H22O11",
+	"ethanol":   "C2H6O",
+	"glycerol":  "C3H8O3",
+	"urea":      "CH4N2O",
+	"glycine":   "C2H5NO2",
+	"imidazole": "C3H4N2",
+	"tris":      "C4H11NO3",
+	"hepes":     "C8H18N2O4S",
+	"edta":      "C10H16N2O8",
+	"dtt":       "C4H10O2S2",
+	"sds":       "NaC12H25SO4",
+	"atp":       "C10H16N5O13P3",
+}
+
+// ParseCompound parses either the name of a compound in Compounds or a chemical formula
+func ParseCompound(compound string) (Formula, error) {
+	if formula, ok := Compounds[strings.ToLower(compound)]; ok {
+		return ParseFormula(formula)
+	}
+	return ParseFormula(compound)
+}
@@ -0,0 +1,149 @@
+package chem
+
+// Element is a chemical element
+type Element struct {
+	Number int
+	Symbol string
+	Name   string
+	// AtomicWeight is the standard atomic weight in g/mol,
+	// or the mass number of the longest-lived isotope for elements without a standard atomic weight.
+	AtomicWeight float64
+}
+
+// Elements lists all elements by atomic number, with the 2021 IUPAC abridged standard atomic weights.
+//
+// Elements with an interval of atomic weights, like H or C, use the conventional value.
+var Elements = []Element{
+	{Number: 1, Symbol: "H", Name: "Hydrogen", AtomicWeight: 1.008},
+	{Number: 2, Symbol: "He", Name: "Helium", AtomicWeight: 4.0026},
+	{Number: 3, Symbol: "Li", Name: "Lithium", AtomicWeight: 6.94},
+	{Number: 4, Symbol: "Be", Name: "Beryllium", AtomicWeight: 9.0122},
+	{Number: 5, Symbol: "B", Name: "Boron", AtomicWeight: 10.81},
+	{Number: 6, Symbol: "C", Name: "Carbon", AtomicWeight: 12.011},
+	{Number: 7, Symbol: "N", Name: "Nitrogen", AtomicWeight: 14.007},
+	{Number: 8, Symbol: "O", Name: "Oxygen", AtomicWeight: 15.999},
+	{Number: 9, Symbol: "F", Name: "Fluorine", AtomicWeight: 18.998},
+	{Number: 10, Symbol: "Ne", Name: "Neon", AtomicWeight: 20.180},
+	{Number: 11, Symbol: "Na", Name: "Sodium", AtomicWeight: 22.990},
+	{Number: 12, Symbol: "Mg", Name: "Magnesium", AtomicWeight: 24.305},
+	{Number: 13, Symbol: "Al", Name: "Aluminium", AtomicWeight: 26.982},
+	{Number: 14, Symbol: "Si", Name: "Silicon", AtomicWeight: 28.085},
+	{Number: 15, Symbol: "P", Name: "Phosphorus", AtomicWeight: 30.974},
+	{Number: 16, Symbol: "S", Name: "Sulfur", AtomicWeight: 32.06},
+	{Number: 17, Symbol: "Cl", Name: "Chlorine", AtomicWeight: 35.45},
+	{Number: 18, Symbol: "Ar", Name: "Argon", AtomicWeight: 39.95},
+	{Number: 19, Symbol: "K", Name: "Potassium", AtomicWeight: 39.098},
+	{Number: 20, Symbol: "Ca", Name: "Calcium", AtomicWeight: 40.078},
+	{Number: 21, Symbol: "Sc", Name: "Scandium", AtomicWeight: 44.956},
+	{Number: 22, Symbol: "Ti", Name: "Titanium", AtomicWeight: 47.867},
+	{Number: 23, Symbol: "V", Name: "Vanadium", AtomicWeight: 50.942},
+	{Number: 24, Symbol: "Cr", Name: "Chromium", AtomicWeight: 51.996},
+	{Number: 25, Symbol: "Mn", Name: "Manganese", AtomicWeight: 54.938},
+	{Number: 26, Symbol: "Fe", Name: "Iron", AtomicWeight: 55.845},
+	{Number: 27, Symbol: "Co", Name: "Cobalt", AtomicWeight: 58.933},
+	{Number: 28, Symbol: "Ni", Name: "Nickel", AtomicWeight: 58.693},
+	{Number: 29, Symbol: "Cu", Name: "Copper", AtomicWeight: 63.546},
+	{Number: 30, Symbol: "Zn", Name: "Zinc", AtomicWeight: 65.38},
+	{Number: 31, Symbol: "Ga", Name: "Gallium", AtomicWeight: 69.723},
+	{Number: 32, Symbol: "Ge", Name: "Germanium", AtomicWeight: 72.630},
+	{Number: 33, Symbol: "As", Name: "Arsenic", AtomicWeight: 74.922},
+	{Number: 34, Symbol: "Se", Name: "Selenium", AtomicWeight: 78.971},
+	{Number: 35, Symbol: "Br", Name: "Bromine", AtomicWeight: 79.904},
+	{Number: 36, Symbol: "Kr", Name: "Krypton", AtomicWeight: 83.798},
+	{Number: 37, Symbol: "Rb", Name: "Rubidium", AtomicWeight: 85.468},
+	{Number: 38, Symbol: "Sr", Name: "Strontium", AtomicWeight: 87.62},
+	{Number: 39, Symbol: "Y", Name: "Yttrium", AtomicWeight: 88.906},
+	{Number: 40, Symbol: "Zr", Name: "Zirconium", AtomicWeight: 91.224},
+	{Number: 41, Symbol: "Nb", Name: "Niobium", AtomicWeight: 92.906},
+	{Number: 42, Symbol: "Mo", Name: "Molybdenum", AtomicWeight: 95.95},
+	{Number: 43, Symbol: "Tc", Name: "Technetium", AtomicWeight: 98},
+	{Number: 44, Symbol: "Ru", Name: "Ruthenium", AtomicWeight: 101.07},
+	{Number: 45, Symbol: "Rh", Name: "Rhodium", AtomicWeight: 102.91},
+	{Number: 46, Symbol: "Pd", Name: "Palladium", AtomicWeight: 106.42},
+	{Number: 47, Symbol: "Ag", Name: "Silver", AtomicWeight: 107.87},
+	{Number: 48, Symbol: "Cd", Name: "Cadmium", AtomicWeight: 112.41},
+	{Number: 49, Symbol: "In", Name: "Indium", AtomicWeight: 114.82},
+	{Number: 50, Symbol: "Sn", Name: "Tin", AtomicWeight: 118.71},
+	{Number: 51, Symbol: "Sb", Name: "Antimony", AtomicWeight: 121.76},
+	{Number: 52, Symbol: "Te", Name: "Tellurium", AtomicWeight: 127.60},
+	{Number: 53, Symbol: "I", Name: "Iodine", AtomicWeight: 126.90},
+	{Number: 54, Symbol: "Xe", Name: "Xenon", AtomicWeight: 131.29},
+	{Number: 55, Symbol: "Cs", Name: "Caesium", AtomicWeight: 132.91},
+	{Number: 56, Symbol: "Ba", Name: "Barium", AtomicWeight: 137.33},
+	{Number: 57, Symbol: "La", Name: "Lanthanum", AtomicWeight: 138.91},
+	{Number: 58, Symbol: "Ce", Name: "Cerium", AtomicWeight: 140.12},
+	{Number: 59, Symbol: "Pr", Name: "Praseodymium", AtomicWeight: 140.91},
+	{Number: 60, Symbol: "Nd", Name: "Neodymium", AtomicWeight: 144.24},
+	{Number: 61, Symbol: "Pm", Name: "Promethium", AtomicWeight: 145},
+	{Number: 62, Symbol: "Sm", Name: "Samarium", AtomicWeight: 150.36},
+	{Number: 63, Symbol: "Eu", Name: "Europium", AtomicWeight: 151.96},
+	{Number: 64, Symbol: "Gd", Name: "Gadolinium", AtomicWeight: 157.25},
+	{Number: 65, Symbol: "Tb", Name: "Terbium", AtomicWeight: 158.93},
+	{Number: 66, Symbol: "Dy", Name: "Dysprosium", AtomicWeight: 162.50},
+	{Number: 67, Symbol: "Ho", Name: "Holmium", AtomicWeight: 164.93},
+	{Number: 68, Symbol: "Er", Name: "Erbium", AtomicWeight: 167.26},
+	{Number: 69, Symbol: "Tm", Name: "Thulium", AtomicWeight: 168.93},
+	{Number: 70, Symbol: "Yb", Name: "Ytterbium", AtomicWeight: 173.05},
+	{Number: 71, Symbol: "Lu", Name: "Lutetium", AtomicWeight: 174.97},
+	{Number: 72, Symbol: "Hf", Name: "Hafnium", AtomicWeight: 178.49},
+	{Number: 73, Symbol: "Ta", Name: "Tantalum", AtomicWeight: 180.95},
+	{Number: 74, Symbol: "W", Name: "Tungsten", AtomicWeight: 183.84},
+	{Number: 75, Symbol: "Re", Name: "Rhenium", AtomicWeight: 186.21},
+	{Number: 76, Symbol: "Os", Name: "Osmium", AtomicWeight: 190.23},
+	{Number: 77, Symbol: "Ir", Name: "Iridium", AtomicWeight: 192.22},
+	{Number: 78, Symbol: "Pt", Name: "Platinum", AtomicWeight: 195.08},
+	{Number: 79, Symbol: "Au", Name: "Gold", AtomicWeight: 196.97},
+	{Number: 80, Symbol: "Hg", Name: "Mercury", AtomicWeight: 200.59},
+	{Number: 81, Symbol: "Tl", Name: "Thallium", AtomicWeight: 204.38},
+	{Number: 82, Symbol: "Pb", Name: "Lead", AtomicWeight: 207.2},
+	{Number: 83, Symbol: "Bi", Name: "Bismuth", AtomicWeight: 208.98},
+	{Number: 84, Symbol: "Po", Name: "Polonium", AtomicWeight: 209},
+	{Number: 85, Symbol: "At", Name: "Astatine", AtomicWeight: 210},
+	{Number: 86, Symbol: "Rn", Name: "Radon", AtomicWeight: 222},
+	{Number: 87, Symbol: "Fr", Name: "Francium", AtomicWeight: 223},
+	{Number: 88, Symbol: "Ra", Name: "Radium", AtomicWeight: 226},
+	{Number: 89, Symbol: "Ac", Name: "Actinium", AtomicWeight: 227},
+	{Number: 90, Symbol: "Th", Name: "Thorium", AtomicWeight: 232.04},
+	{Number: 91, Symbol: "Pa", Name: "Protactinium", AtomicWeight: 231.04},
+	{Number: 92, Symbol: "U", Name: "Uranium", AtomicWeight: 238.03},
+	{Number: 93, Symbol: "Np", Name: "Neptunium", AtomicWeight: 237},
+	{Number: 94, Symbol: "Pu", Name: "Plutonium", AtomicWeight: 244},
+	{Number: 95, Symbol: "Am", Name: "Americium", AtomicWeight: 243},
+	{Number: 96, Symbol: "Cm", Name: "Curium", AtomicWeight: 247},
+	{Number: 97, Symbol: "Bk", Name: "Berkelium", AtomicWeight: 247},
+	{Number: 98, Symbol: "Cf", Name: "Californium", AtomicWeight: 251},
+	{Number: 99, Symbol: "Es", Name: "Einsteinium", AtomicWeight: 252},
+	{Number: 100, Symbol: "Fm", Name: "Fermium", AtomicWeight: 257},
+	{Number: 101, Symbol: "Md", Name: "Mendelevium", AtomicWeight: 258},
+	{Number: 102, Symbol: "No", Name: "Nobelium", AtomicWeight: 259},
+	{Number: 103, Symbol: "Lr", Name: "Lawrencium", AtomicWeight: 266},
+	{Number: 104, Symbol: "Rf", Name: "Rutherfordium", AtomicWeight: 267},
+	{Number: 105, Symbol: "Db", Name: "Dubnium", AtomicWeight: 268},
+	{Number: 106, Symbol: "Sg", Name: "Seaborgium", AtomicWeight: 269},
+	{Number: 107, Symbol: "Bh", Name: "Bohrium", AtomicWeight: 270},
+	{Number: 108, Symbol: "Hs", Name: "Hassium", AtomicWeight: 269},
+	{Number: 109, Symbol: "Mt", Name: "Meitnerium", AtomicWeight: 278},
+	{Number: 110, Symbol: "Ds", Name: "Darmstadtium", AtomicWeight: 281},
+	{Number: 111, Symbol: "Rg", Name: "Roentgenium", AtomicWeight: 282},
+	{Number: 112, Symbol: "Cn", Name: "Copernicium", AtomicWeight: 285},
+	{Number: 113, Symbol: "Nh", Name: "Nihonium", AtomicWeight: 286},
+	{Number: 114, Symbol: "Fl", Name: "Flerovium", AtomicWeight: 289},
+	{Number: 115, Symbol: "Mc", Name: "Moscovium", AtomicWeight: 290},
+	{Number: 116, Symbol: "Lv", Name: "Livermorium", AtomicWeight: 293},
+	{Number: 117, Symbol: "Ts", Name: "Tennessine", AtomicWeight: 294},
+	{Number: 118, Symbol: "Og", Name: "Oganesson", AtomicWeight: 294},
+}
+
+var elementsBySymbol = func() map[string]Element {
+	res := make(map[string]Element, len(Elements))
+	for _, e := range Elements {
+		res[e.Symbol] = e
+	}
+	return res
+}()
+
+// LookupElement finds the element with the given symbol
+func LookupElement(symbol string) (Element, bool) {
+	e, ok := elementsBySymbol[symbol]
+	return e, ok
+}
@@ -0,0 +1,104 @@
+package chem
+
+import (
+	"github.com/eternal-flame-ad/unitdc/localize"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type ErrUnknownElement struct {
+	Formula  string
+	Position int
+	Symbol   string
+}
+
+func (e ErrUnknownElement) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "ChemError_UnknownElement",
+			Other: "unknown element {{.Symbol}} at position {{.Position}} of formula {{.Formula}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Formula":  e.Formula,
+			"Position": e.Position,
+			"Symbol":   e.Symbol,
+		},
+	})
+}
+
+type ErrUnexpectedCharacter struct {
+	Formula   string
+	Position  int
+	Character string
+}
+
+func (e ErrUnexpectedCharacter) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "ChemError_UnexpectedCharacter",
+			Other: "unexpected \"{{.Character}}\" at position {{.Position}} of formula {{.Formula}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Formula":   e.Formula,
+			"Position":  e.Position,
+			"Character": e.Character,
+		},
+	})
+}
+
+type ErrUnexpectedEnd struct {
+	Formula  string
+	Position int
+}
+
+func (e ErrUnexpectedEnd) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "ChemError_UnexpectedEnd",
+			Other: "unexpected end at position {{.Position}} of formula {{.Formula}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Formula":  e.Formula,
+			"Position": e.Position,
+		},
+	})
+}
+
+type ErrUnclosedGroup struct {
+	Formula   string
+	Position  int
+	Character string
+}
+
+func (e ErrUnclosedGroup) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "ChemError_UnclosedGroup",
+			Other: "unclosed \"{{.Character}}\" at position {{.Position}} of formula {{.Formula}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Formula":   e.Formula,
+			"Position":  e.Position,
+			"Character": e.Character,
+		},
+	})
+}
+
+type ErrInvalidCount struct {
+	Formula  string
+	Position int
+	Count    string
+}
+
+func (e ErrInvalidCount) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "ChemError_InvalidCount",
+			Other: "invalid count {{.Count}} at position {{.Position}} of formula {{.Formula}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Formula":  e.Formula,
+			"Position": e.Position,
+			"Count":    e.Count,
+		},
+	})
+}
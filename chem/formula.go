@@ -0,0 +1,201 @@
+package chem
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"unicode"
+)
+
+// Formula is the composition of a chemical formula, the number of atoms of each element by symbol
+type Formula map[string]int
+
+// hydrateSeparators separate the parts of a hydrate or adduct, like CuSO4·5H2O
+var hydrateSeparators = map[rune]bool{
+	'·': true,
+	'•': true,
+	'.': true,
+	'*': true,
+}
+
+// groupDelimiters map the characters opening a group to the characters closing it
+var groupDelimiters = map[rune]rune{
+	'(': ')',
+	'[': ']',
+}
+
+// ParseFormula parses a chemical formula like C6H12O6, Ca(OH)2 or CuSO4·5H2O.
+//
+// Groups in parentheses or brackets may be followed by a count.
+// Parts of hydrates or adducts are separated by "·", ".", or "*", and may start with a coefficient.
+func ParseFormula(formula string) (Formula, error) {
+	p := &formulaParser{formula: formula, runes: []rune(formula)}
+	return p.parse()
+}
+
+// MolarMass computes the molar mass in g/mol
+func (f Formula) MolarMass() float64 {
+	res, _ := f.MolarMassRat().Float64()
+	return res
+}
+
+// MolarMassRat computes the molar mass in g/mol exactly from the decimal atomic weights
+func (f Formula) MolarMassRat() *big.Rat {
+	res := new(big.Rat)
+	for symbol, count := range f {
+		e, _ := LookupElement(symbol)
+		weight, _ := new(big.Rat).SetString(strconv.FormatFloat(e.AtomicWeight, 'g', -1, 64))
+		res.Add(res, weight.Mul(weight, new(big.Rat).SetInt64(int64(count))))
+	}
+	return res
+}
+
+// add adds count times f2 to f, ok is false if a number of atoms overflows an int
+func (f Formula) add(f2 Formula, count int) (ok bool) {
+	for symbol, n := range f2 {
+		if n > (math.MaxInt-f[symbol])/count {
+			return false
+		}
+		f[symbol] += n * count
+	}
+	return true
+}
+
+type formulaParser struct {
+	formula string
+	runes   []rune
+	pos     int
+}
+
+func (p *formulaParser) peek() (rune, bool) {
+	if p.pos >= len(p.runes) {
+		return 0, false
+	}
+	return p.runes[p.pos], true
+}
+
+// unexpected reports the character at the current position, or the end of the formula
+func (p *formulaParser) unexpected() error {
+	r, ok := p.peek()
+	if !ok {
+		return ErrUnexpectedEnd{Formula: p.formula, Position: p.pos + 1}
+	}
+	return ErrUnexpectedCharacter{Formula: p.formula, Position: p.pos + 1, Character: string(r)}
+}
+
+func (p *formulaParser) parse() (Formula, error) {
+	res := make(Formula)
+	for {
+		coefficient, err := p.count()
+		if err != nil {
+			return nil, err
+		}
+		part, err := p.groups()
+		if err != nil {
+			return nil, err
+		}
+		if len(part) == 0 {
+			return nil, p.unexpected()
+		}
+		if !res.add(part, coefficient.n) {
+			return nil, p.invalidCount(coefficient)
+		}
+
+		r, ok := p.peek()
+		if !ok {
+			return res, nil
+		}
+		if !hydrateSeparators[r] {
+			return nil, p.unexpected()
+		}
+		p.pos++
+	}
+}
+
+// formulaCount is a count of atoms or groups read from a formula
+type formulaCount struct {
+	n     int
+	start int
+	// literal is the count as written, empty if the count of 1 is implied
+	literal string
+}
+
+// count reads an optional count of atoms or groups, 1 if there is none.
+// Counts must be positive and fit into an int.
+func (p *formulaParser) count() (formulaCount, error) {
+	res := formulaCount{n: 1, start: p.pos}
+	for r, ok := p.peek(); ok && r >= '0' && r <= '9'; r, ok = p.peek() {
+		p.pos++
+	}
+	if res.start == p.pos {
+		return res, nil
+	}
+	res.literal = string(p.runes[res.start:p.pos])
+	n, err := strconv.Atoi(res.literal)
+	if err != nil || n == 0 {
+		return res, p.invalidCount(res)
+	}
+	res.n = n
+	return res, nil
+}
+
+// invalidCount reports a count that is zero, or that makes a number of atoms overflow
+func (p *formulaParser) invalidCount(c formulaCount) error {
+	literal := c.literal
+	if literal == "" {
+		literal = "1"
+	}
+	return ErrInvalidCount{Formula: p.formula, Position: c.start + 1, Count: literal}
+}
+
+// groups reads elements and groups until a character that can not start either of them
+func (p *formulaParser) groups() (Formula, error) {
+	res := make(Formula)
+	for {
+		r, ok := p.peek()
+		switch {
+		case !ok:
+			return res, nil
+		case unicode.IsUpper(r):
+			start := p.pos
+			p.pos++
+			for r, ok := p.peek(); ok && unicode.IsLower(r); r, ok = p.peek() {
+				p.pos++
+			}
+			symbol := string(p.runes[start:p.pos])
+			if _, ok := LookupElement(symbol); !ok {
+				return nil, ErrUnknownElement{Formula: p.formula, Position: start + 1, Symbol: symbol}
+			}
+			count, err := p.count()
+			if err != nil {
+				return nil, err
+			}
+			if !res.add(Formula{symbol: 1}, count.n) {
+				return nil, p.invalidCount(count)
+			}
+		case groupDelimiters[r] != 0:
+			start := p.pos
+			p.pos++
+			group, err := p.groups()
+			if err != nil {
+				return nil, err
+			}
+			if len(group) == 0 {
+				return nil, p.unexpected()
+			}
+			if closing, ok := p.peek(); !ok || closing != groupDelimiters[r] {
+				return nil, ErrUnclosedGroup{Formula: p.formula, Position: start + 1, Character: string(r)}
+			}
+			p.pos++
+			count, err := p.count()
+			if err != nil {
+				return nil, err
+			}
+			if !res.add(group, count.n) {
+				return nil, p.invalidCount(count)
+			}
+		default:
+			return res, nil
+		}
+	}
+}
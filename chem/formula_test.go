@@ -0,0 +1,86 @@
+package chem
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFormula(t *testing.T) {
+	Convey("Chemical formulas", t, func() {
+		Convey("should count atoms", func() {
+			f, err := ParseFormula("C6H12O6")
+			So(err, ShouldBeNil)
+			So(f, ShouldResemble, Formula{"C": 6, "H": 12, "O": 6})
+
+			f, err = ParseFormula("Ca(OH)2")
+			So(err, ShouldBeNil)
+			So(f, ShouldResemble, Formula{"Ca": 1, "O": 2, "H": 2})
+
+			f, err = ParseFormula("K4[Fe(CN)6]")
+			So(err, ShouldBeNil)
+			So(f, ShouldResemble, Formula{"K": 4, "Fe": 1, "C": 6, "N": 6})
+		})
+		Convey("should count hydrates", func() {
+			for _, formula := range []string{"CuSO4·5H2O", "CuSO4.5H2O", "CuSO4*5H2O"} {
+				f, err := ParseFormula(formula)
+				So(err, ShouldBeNil)
+				So(f, ShouldResemble, Formula{"Cu": 1, "S": 1, "O": 9, "H": 10})
+			}
+		})
+		Convey("should compute the molar mass", func() {
+			f, _ := ParseFormula("C6H12O6")
+			So(f.MolarMass(), ShouldAlmostEqual, 180.156, 1e-9)
+			So(f.MolarMassRat().RatString(), ShouldEqual, "45039/250")
+
+			f, _ = ParseFormula("NaCl")
+			So(f.MolarMass(), ShouldAlmostEqual, 58.44, 1e-9)
+		})
+		Convey("should know common compounds", func() {
+			f, err := ParseCompound("Glucose")
+			So(err, ShouldBeNil)
+			So(f, ShouldResemble, Formula{"C": 6, "H": 12, "O": 6})
+
+			f, err = ParseCompound("NaCl")
+			So(err, ShouldBeNil)
+			So(f, ShouldResemble, Formula{"Na": 1, "Cl": 1})
+		})
+		Convey("should report the position of errors", func() {
+			_, err := ParseFormula("NaXy")
+			So(err, ShouldResemble, ErrUnknownElement{Formula: "NaXy", Position: 3, Symbol: "Xy"})
+
+			_, err = ParseFormula("Ca(OH2")
+			So(err, ShouldResemble, ErrUnclosedGroup{Formula: "Ca(OH2", Position: 3, Character: "("})
+
+			_, err = ParseFormula("H2O)")
+			So(err, ShouldResemble, ErrUnexpectedCharacter{Formula: "H2O)", Position: 4, Character: ")"})
+
+			_, err = ParseFormula("CuSO4·")
+			So(err, ShouldResemble, ErrUnexpectedEnd{Formula: "CuSO4·", Position: 7})
+
+			_, err = ParseFormula("")
+			So(err, ShouldResemble, ErrUnexpectedEnd{Formula: "", Position: 1})
+
+			_, err = ParseFormula("h2o")
+			So(err, ShouldResemble, ErrUnexpectedCharacter{Formula: "h2o", Position: 1, Character: "h"})
+
+			_, err = ParseFormula("H2O0")
+			So(err, ShouldResemble, ErrInvalidCount{Formula: "H2O0", Position: 4, Count: "0"})
+
+			_, err = ParseFormula("C99999999999999999999H4")
+			So(err, ShouldResemble, ErrInvalidCount{Formula: "C99999999999999999999H4", Position: 2, Count: "99999999999999999999"})
+
+			_, err = ParseFormula("(H99999999999)99999999999")
+			So(err, ShouldResemble, ErrInvalidCount{Formula: "(H99999999999)99999999999", Position: 15, Count: "99999999999"})
+
+			_, err = ParseFormula("C9223372036854775807C")
+			So(err, ShouldResemble, ErrInvalidCount{Formula: "C9223372036854775807C", Position: 22, Count: "1"})
+
+			_, err = ParseFormula("99999999999(H99999999999)")
+			So(err, ShouldResemble, ErrInvalidCount{Formula: "99999999999(H99999999999)", Position: 1, Count: "99999999999"})
+
+			_, err = ParseFormula("CuSO4·0H2O")
+			So(err, ShouldResemble, ErrInvalidCount{Formula: "CuSO4·0H2O", Position: 7, Count: "0"})
+		})
+	})
+}
@@ -2,26 +2,149 @@ package main
 
 import (
 	"bufio"
+	"flag"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/eternal-flame-ad/unitdc/interpreter"
+	"github.com/eternal-flame-ad/unitdc/quantity"
 	"github.com/eternal-flame-ad/unitdc/repl"
 )
 
+var (
+	precision  = flag.Uint("prec", 0, "compute with arbitrary precision numbers of this many bits, instead of float64")
+	exact      = flag.Bool("exact", false, "compute with exact rational numbers where possible")
+	fractions  = flag.Bool("frac", false, "display exact numbers as fractions")
+	unicode    = flag.Bool("unicode", repl.TerminalSupportsUnicode(os.Stdout), "display units with Unicode symbols like µg·mL⁻¹, the default on terminals that support it")
+	autoUnits  = flag.Bool("auto", false, "display quantities in the simplest derived units, like (Da) for (g)(mol)-1")
+	autoPrefix = flag.Bool("prefix", false, "display quantities with the prefix that keeps the number between 1 and 1000, like (ug) for 0.000025 (g)")
+	listUnits  = flag.Bool("list-units", false, "list all known units and exit")
+	unitFiles  definitionFiles
+	unitSets   definitionFiles
+	session    = flag.String("session", "", "load units defined in an earlier session from this file, and save new units to it")
+)
+
+func init() {
+	flag.Var(&unitFiles, "units", "load unit definitions from this JSON file, may be repeated")
+	flag.Var(&unitSets, "unitset", "load a builtin unit set, one of "+strings.Join(quantity.UnitSets(), ", ")+", may be repeated")
+}
+
+// definitionFiles is a list of unit definition files given on the command line
+type definitionFiles []string
+
+func (d *definitionFiles) String() string {
+	return strings.Join(*d, ",")
+}
+
+func (d *definitionFiles) Set(path string) error {
+	*d = append(*d, path)
+	return nil
+}
+
+// defaultDefinitionFile is loaded on start if it exists, before any files given on the command line
+func defaultDefinitionFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "unitdc", "units.json")
+}
+
+func loadDefinitions(registry *quantity.Registry) error {
+	for _, name := range unitSets {
+		if err := registry.LoadUnitSet(name); err != nil {
+			return err
+		}
+	}
+	paths := unitFiles
+	if path := defaultDefinitionFile(); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			paths = append(definitionFiles{path}, paths...)
+		}
+	}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		err = registry.LoadDefinitions(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
 var (
 	input       = bufio.NewScanner(os.Stdin)
 	output      = os.Stdout
 	outputError = os.Stderr
 )
 
+func loadSession(interp *interpreter.State, path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	defs, err := quantity.ParseDefinitions(f)
+	if err == nil {
+		err = interp.LoadSession(defs)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
 func main() {
+	flag.Parse()
+
 	r := &repl.R{
-		Input:     input,
-		Output:    output,
-		OutputErr: outputError,
+		Input:      input,
+		Output:     output,
+		OutputErr:  outputError,
+		Fractions:  *fractions,
+		Unicode:    *unicode,
+		AutoUnits:  *autoUnits,
+		AutoPrefix: *autoPrefix,
+	}
+	registry := quantity.NewDefaultRegistry()
+	if err := loadDefinitions(registry); err != nil {
+		r.PrintError(err)
+		os.Exit(1)
+	}
+	interp := interpreter.NewState(registry, r, r)
+	r.Registry = registry
+	if *session != "" {
+		if err := loadSession(interp, *session); err != nil {
+			r.PrintError(err)
+			os.Exit(1)
+		}
+		r.SessionFile = *session
+	}
+	if *listUnits {
+		if err := r.PrintUnits(interp.Registry); err != nil {
+			panic(err)
+		}
+		return
+	}
+	if *exact {
+		interp.Numbers = quantity.NumberContext{
+			Mode: quantity.NumberModeRational,
+		}
+	} else if *precision != 0 {
+		interp.Numbers = quantity.NumberContext{
+			Mode:      quantity.NumberModeBigFloat,
+			Precision: *precision,
+		}
 	}
-	interp := interpreter.NewDefaultState(r, r)
 	for {
 		if err := r.WritePrompt(); err != nil {
 			panic(err)
@@ -2,9 +2,10 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
-	"strconv"
+	"strings"
 	"syscall/js"
 
 	"github.com/eternal-flame-ad/unitdc/interpreter"
@@ -16,6 +17,14 @@ import (
 type wasmIO struct {
 	inputTokens []syntax.Token
 
+	// fractions displays exact numbers as fractions
+	fractions bool
+	// autoUnits displays quantities in the simplest derived units of registry
+	autoUnits bool
+	// autoPrefix displays quantities with the prefix that keeps the number in [1, 1000)
+	autoPrefix bool
+	registry   *quantity.Registry
+
 	outputFunc js.Value
 }
 
@@ -31,7 +40,7 @@ func (w *wasmIO) ReadToken() (tok syntax.Token, err error) {
 func (w *wasmIO) PrintQuantity(values []quantity.Q) (err error) {
 	jsValues := make([]interface{}, len(values))
 	for i := range values {
-		jsValues[i] = quantityAsJSValue(values[i])
+		jsValues[i] = w.quantityAsJSValue(values[i])
 	}
 	w.outputFunc.Invoke(
 		"quantity",
@@ -40,6 +49,26 @@ func (w *wasmIO) PrintQuantity(values []quantity.Q) (err error) {
 	return nil
 }
 
+func (w *wasmIO) PrintWarning(warning error) error {
+	w.outputFunc.Invoke(
+		"warning",
+		warning.Error(),
+	)
+	return nil
+}
+
+func (w *wasmIO) SaveDefinitions(defs quantity.Definitions) error {
+	data, err := json.Marshal(defs)
+	if err != nil {
+		return err
+	}
+	w.outputFunc.Invoke(
+		"definitions",
+		string(data),
+	)
+	return nil
+}
+
 func (w *wasmIO) PrintError(err error) error {
 	w.outputFunc.Invoke(
 		"error",
@@ -50,35 +79,39 @@ func (w *wasmIO) PrintError(err error) error {
 
 type wasmIOState struct {
 	QuantitiesOnStack []quantity.Q
+	Registry          *quantity.Registry
 }
 
-func quantityAsDisplayStr(q quantity.Q) string {
-	num, unit := q.Format()
-	unitStr := ""
-	for _, u := range unit {
-		unitStr += fmt.Sprintf("(%s)", u.Identifier)
-		if u.Exponent != 1 {
-			unitStr += strconv.Itoa(u.Exponent) + " "
-		}
+func (w *wasmIO) formatOptions() (opts quantity.FormatOptions) {
+	if w.registry != nil {
+		return w.registry.FormatOptions(w.autoUnits, w.autoPrefix)
 	}
-	return fmt.Sprintf("%f %s", num, unitStr)
+	opts.AutoPrefix = w.autoPrefix
+	return
 }
 
-func quantityAsJSValue(q quantity.Q) js.Value {
-	num, list := q.Format()
+func (w *wasmIO) quantityAsDisplayStr(q quantity.Q) string {
+	return q.DisplayWith(w.formatOptions()).String(w.fractions)
+}
+
+func (w *wasmIO) quantityAsJSValue(q quantity.Q) js.Value {
+	display := q.DisplayWith(w.formatOptions())
+	list := display.Units
 	listAsIface := make([]interface{}, len(list))
 	for i := range list {
 		listAsIface[i] = map[string]interface{}{
-			"Exponent":   list[i].Exponent,
+			"Exponent":   list[i].Exponent.Float64(),
 			"Identifier": list[i].Identifier,
 		}
 	}
 	return js.ValueOf(
 		map[string]interface{}{
 			"display": map[string]interface{}{
-				"num":  num,
-				"unit": listAsIface,
-				"str":  quantityAsDisplayStr(q),
+				"num":         display.Number.Float64(),
+				"uncertainty": display.Uncertainty,
+				"unit":        listAsIface,
+				"str":         w.quantityAsDisplayStr(q),
+				"html":        display.HTML(w.fractions),
 			},
 		},
 	)
@@ -87,13 +120,19 @@ func quantityAsJSValue(q quantity.Q) js.Value {
 func (w *wasmIO) RequestMoreInput(state wasmIOState) {
 	stack := make([]interface{}, len(state.QuantitiesOnStack))
 	for i := range stack {
-		stack[i] = quantityAsJSValue(state.QuantitiesOnStack[i])
+		stack[i] = w.quantityAsJSValue(state.QuantitiesOnStack[i])
+	}
+	identifiers := state.Registry.Identifiers()
+	units := make([]interface{}, len(identifiers))
+	for i := range identifiers {
+		units[i] = identifiers[i]
 	}
 	w.outputFunc.Invoke(
 		"ready",
 		map[string]interface{}{
 			"state": map[string]interface{}{
 				"stack": stack,
+				"units": units,
 			},
 		},
 	)
@@ -101,7 +140,8 @@ func (w *wasmIO) RequestMoreInput(state wasmIOState) {
 
 func main() {
 	wasmio := &wasmIO{}
-	interp := interpreter.NewDefaultState(wasmio, wasmio)
+	interp := interpreter.NewState(quantity.NewDefaultRegistry(), wasmio, wasmio)
+	wasmio.registry = interp.Registry
 
 	wasmio.outputFunc = js.Global().Get("unitdc_init").Invoke(
 		js.FuncOf(func(this js.Value, p []js.Value) interface{} {
@@ -122,6 +162,52 @@ func main() {
 				}
 				wasmio.RequestMoreInput(wasmIOState{
 					QuantitiesOnStack: interp.StackCopy(),
+					Registry:          interp.Registry,
+				})
+			case "config":
+				configDef := p[1]
+				if precision := configDef.Get("precision"); precision.Truthy() {
+					interp.Numbers = quantity.NumberContext{
+						Mode:      quantity.NumberModeBigFloat,
+						Precision: uint(precision.Int()),
+					}
+				} else if !precision.IsUndefined() {
+					interp.Numbers = quantity.NumberContext{}
+				}
+				if exact := configDef.Get("exact"); exact.Truthy() {
+					interp.Numbers = quantity.NumberContext{
+						Mode: quantity.NumberModeRational,
+					}
+				} else if !exact.IsUndefined() && interp.Numbers.Mode == quantity.NumberModeRational {
+					interp.Numbers = quantity.NumberContext{}
+				}
+				if fractions := configDef.Get("fractions"); !fractions.IsUndefined() {
+					wasmio.fractions = fractions.Truthy()
+				}
+				if auto := configDef.Get("auto"); !auto.IsUndefined() {
+					wasmio.autoUnits = auto.Truthy()
+				}
+				if prefix := configDef.Get("prefix"); !prefix.IsUndefined() {
+					wasmio.autoPrefix = prefix.Truthy()
+				}
+			case "units":
+				unitsDef := p[1]
+				definitions := unitsDef.Get("definitions").String()
+				if err := interp.Registry.LoadDefinitions(strings.NewReader(definitions)); err != nil {
+					wasmio.PrintError(err)
+				}
+				wasmio.RequestMoreInput(wasmIOState{
+					QuantitiesOnStack: interp.StackCopy(),
+					Registry:          interp.Registry,
+				})
+			case "unitset":
+				name := p[1].Get("name").String()
+				if err := interp.Registry.LoadUnitSet(name); err != nil {
+					wasmio.PrintError(err)
+				}
+				wasmio.RequestMoreInput(wasmIOState{
+					QuantitiesOnStack: interp.StackCopy(),
+					Registry:          interp.Registry,
 				})
 			default:
 				wasmio.PrintError(fmt.Errorf("unknown WASM ABI input type: %s", inputType))
@@ -130,8 +216,20 @@ func main() {
 		}),
 	)
 
+	// units defined in earlier sessions, saved by the page from the "definitions" output
+	if session := js.Global().Get("unitdc_session"); session.Type() == js.TypeString {
+		defs, err := quantity.ParseDefinitions(strings.NewReader(session.String()))
+		if err == nil {
+			err = interp.LoadSession(defs)
+		}
+		if err != nil {
+			wasmio.PrintError(err)
+		}
+	}
+
 	wasmio.RequestMoreInput(wasmIOState{
 		QuantitiesOnStack: interp.StackCopy(),
+		Registry:          interp.Registry,
 	})
 
 	select {}
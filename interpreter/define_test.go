@@ -0,0 +1,95 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDefine(t *testing.T) {
+	Convey("Operator define", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+
+		Convey("should define a unit usable afterwards", func() {
+			mockInput.tokenize(`2.5 (mg) "vial" define 3 (vial) (mg) p`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockInterpreter.StackDepth(), ShouldEqual, 1)
+			num, unit := mockOutput.outputQuantities[0].Format()
+			So(num.Float64(), ShouldAlmostEqual, 7.5)
+			So(unit, ShouldResemble, quantity.UnitDisplayList{{Identifier: "mg", Exponent: quantity.IntExponent(1)}})
+
+			Convey("which accepts prefixes", func() {
+				mockInput.tokenize(`1 (kvial) (g)`)
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors)
+				So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number.Float64(), ShouldAlmostEqual, 2.5)
+			})
+			Convey("and is saved with the session", func() {
+				So(mockOutput.savedDefinitions, ShouldNotBeNil)
+				So(mockOutput.savedDefinitions.Derived, ShouldHaveLength, 1)
+				So(mockOutput.savedDefinitions.Derived[0].Identifier, ShouldEqual, "vial")
+
+				restored := NewDefaultState(mockInput, mockOutput)
+				So(restored.LoadSession(*mockOutput.savedDefinitions), ShouldBeNil)
+				_, vial := restored.Registry.LookupExact("vial")
+				So(vial.Multiplier, ShouldAlmostEqual, 2.5e-3)
+				So(restored.Defined.Derived, ShouldHaveLength, 1)
+			})
+			Convey("together with the substances and unit sets of the restored session", func() {
+				restored := NewDefaultState(mockInput, mockOutput)
+				defs := *mockOutput.savedDefinitions
+				defs.Requires = []string{"us"}
+				defs.Substances = []quantity.Substance{{Name: "heparin", Mass: 5.5e-6}}
+				So(restored.LoadSession(defs), ShouldBeNil)
+				So(restored.Defined.Requires, ShouldResemble, []string{"us"})
+				So(restored.Defined.Substances, ShouldResemble, defs.Substances)
+
+				mockInput.tokenize(`1 (mg) "tablet" define`)
+				So(restored.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors)
+				So(mockOutput.savedDefinitions.Requires, ShouldResemble, []string{"us"})
+				So(mockOutput.savedDefinitions.Substances, ShouldResemble, defs.Substances)
+				So(mockOutput.savedDefinitions.Derived, ShouldHaveLength, 2)
+			})
+			Convey("which can not be redefined", func() {
+				mockInput.tokenize(`1 (mg) "vial" define`)
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors, quantity.ErrDuplicateUnit{})
+				So(mockInterpreter.StackDepth(), ShouldEqual, 2)
+				So(mockInterpreter.Strings, ShouldResemble, []string{"vial"})
+			})
+		})
+		Convey("should keep exact quantities exact", func() {
+			mockInterpreter.Numbers = quantity.NumberContext{Mode: quantity.NumberModeRational}
+			mockInput.tokenize(`1 (g) 3 / "third" define 2 (third) (g) p`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockOutput.outputWarnings, ShouldBeEmpty)
+			num, _ := mockOutput.outputQuantities[0].Format()
+			So(quantity.FormatNumber(num, true), ShouldEqual, "2/3")
+		})
+		Convey("should drop the uncertainty of the quantity", func() {
+			mockInput.tokenize(`1.50±0.02 (g) "scoop" define 2 (scoop) (g)`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 3)
+			So(top().Uncertainty, ShouldEqual, 0)
+		})
+		Convey("should require a name", func() {
+			mockInput.tokenize(`2.5 (mg) define`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrEmptyStringStack{})
+			So(mockInterpreter.StackDepth(), ShouldEqual, 1)
+		})
+		Convey("should reject absolute temperatures and non-positive quantities", func() {
+			mockInput.tokenize(`20 (degC) "room" define`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrAbsoluteScale{})
+			mockInput.tokenize(`c 0 (mg) "nothing" define`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, quantity.ErrInvalidMultiplier{})
+		})
+	})
+}
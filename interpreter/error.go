@@ -19,6 +19,18 @@ func (e ErrEmptyStack) Error() string {
 	})
 }
 
+type ErrEmptyStringStack struct {
+}
+
+func (e ErrEmptyStringStack) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "InterpreterError_StringStackEmpty",
+			Other: "a name is required, like \"vial\"",
+		},
+	})
+}
+
 type ErrIncompatibleUnit struct {
 	TargetUnit    quantity.UCombination
 	OffendingUnit quantity.UCombination
@@ -48,6 +60,22 @@ func (e ErrIncompatibleUnit) Error() string {
 	})
 }
 
+type ErrAbsoluteScale struct {
+	OffendingUnit quantity.UCombination
+}
+
+func (e ErrAbsoluteScale) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "InterpreterError_AbsoluteScale",
+			Other: "incompatible units: absolute {{.OffendingUnit}} is unacceptable for this operation, use a difference instead",
+		},
+		TemplateData: map[string]interface{}{
+			"OffendingUnit": e.OffendingUnit.String(),
+		},
+	})
+}
+
 type ErrUnknownOperation struct {
 	Token syntax.Token
 }
@@ -79,3 +107,53 @@ func (e ErrUnknownUnit) Error() string {
 		},
 	})
 }
+
+type ErrDomain struct {
+	Operation string
+	Number    float64
+}
+
+func (e ErrDomain) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "InterpreterError_Domain",
+			Other: "{{.Operation}} is undefined for {{.Number}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Operation": e.Operation,
+			"Number":    e.Number,
+		},
+	})
+}
+
+type ErrImpossibleDilution struct {
+	Operation string
+}
+
+func (e ErrImpossibleDilution) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "InterpreterError_ImpossibleDilution",
+			Other: "impossible dilution in {{.Operation}}: the final concentration must not exceed the stock concentration",
+		},
+		TemplateData: map[string]interface{}{
+			"Operation": e.Operation,
+		},
+	})
+}
+
+type WarnInexact struct {
+	Operation string
+}
+
+func (e WarnInexact) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "InterpreterWarning_Inexact",
+			Other: "result of {{.Operation}} can not be represented exactly, continuing with floating point numbers",
+		},
+		TemplateData: map[string]interface{}{
+			"Operation": e.Operation,
+		},
+	})
+}
@@ -13,8 +13,16 @@ type State struct {
 	Stack        [64]quantity.Q
 	StackPointer int
 
-	Units        []quantity.U
-	DerivedUnits quantity.UDerivedList
+	// Strings is a separate stack for string literals, like names of new units
+	Strings []string
+
+	// Registry holds all units known to the session
+	Registry *quantity.Registry
+	// Defined holds all units defined during the session, to be saved with the session
+	Defined quantity.Definitions
+
+	// Numbers selects the number representation of the session
+	Numbers quantity.NumberContext
 
 	Output IOutput
 	Input  IInput
@@ -64,38 +72,62 @@ func (s *State) handleToken(t syntax.Token) (err error) {
 			return s.OperatorD()
 		case "r":
 			return s.OperatorR()
+		case "define":
+			return s.OperatorDefine()
+		case "mw":
+			return s.OperatorMW()
+		case "mwconv":
+			return s.OperatorMWConvert()
+		case "ln":
+			return s.OperatorLn()
+		case "log10":
+			return s.OperatorLog10()
+		case "exp":
+			return s.OperatorExp()
+		case "pow10":
+			return s.OperatorPow10()
+		case "dil":
+			return s.OperatorDil()
+		case "dilv":
+			return s.OperatorDilV()
+		case "dilc":
+			return s.OperatorDilC()
+		case "const":
+			return s.OperatorConst()
+		case "dose":
+			return s.OperatorDose()
+		case "bsadose":
+			return s.OperatorBSADose()
+		case "bsa":
+			return s.OperatorBSA()
+		case "bsadubois":
+			return s.OperatorBSADuBois()
+		case "bsahaycock":
+			return s.OperatorBSAHaycock()
 		default:
 			return ErrUnknownOperation{t}
 		}
 	case *syntax.TokenUnit:
 		return s.OperatorUnitConvert(*t)
+	case *syntax.TokenString:
+		s.StringPush(t.Value())
+		return nil
 	default:
 		return ErrUnknownOperation{t}
 	}
 }
 
-func NewDefaultState(input IInput, output IOutput) *State {
+// NewState creates an interpreter state using the units in registry
+func NewState(registry *quantity.Registry, input IInput, output IOutput) *State {
 	return &State{
 		StackPointer: -1,
-		Units: []quantity.U{
-			quantity.UnitGram,
-			quantity.UnitLiter,
-			quantity.UnitIU,
-			quantity.UnitMeter,
-			quantity.UnitMole,
-		},
-		DerivedUnits: func() (res quantity.UDerivedList) {
-			res = append(res, quantity.UnitDerivedAmu)
-			res = append(res, quantity.UnitDerivedAmuEng...)
-			res = append(res, quantity.UnitDerivedMolar)
-			res = append(res, quantity.UnitDerivedMolarEng...)
-			res = append(res, quantity.UnitDerivedGramEng...)
-			res = append(res, quantity.UnitDerivedLiterEng...)
-			res = append(res, quantity.UnitDerivedMeterEng...)
-			res = append(res, quantity.UnitDerivedMoleEng...)
-			return
-		}(),
-		Input:  input,
-		Output: output,
+		Registry:     registry,
+		Input:        input,
+		Output:       output,
 	}
 }
+
+// NewDefaultState creates an interpreter state with all builtin units
+func NewDefaultState(input IInput, output IOutput) *State {
+	return NewState(quantity.NewDefaultRegistry(), input, output)
+}
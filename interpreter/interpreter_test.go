@@ -1,8 +1,10 @@
 package interpreter
 
 import (
+	"math"
 	"testing"
 
+	"github.com/eternal-flame-ad/unitdc/chem"
 	"github.com/eternal-flame-ad/unitdc/quantity"
 	"github.com/eternal-flame-ad/unitdc/syntax"
 	. "github.com/smartystreets/goconvey/convey"
@@ -11,9 +13,7 @@ import (
 func TestInterpreter(t *testing.T) {
 
 	Convey("Test Interpreter", t, func() {
-		mockInput := new(MockedInterpreterInput)
-		mockOutput := new(MockedInterpreterOutput)
-		mockInterpreter := NewDefaultState(mockInput, mockOutput)
+		mockInput, mockOutput, mockInterpreter, _ := newMockedState()
 
 		testErrorOnOneOperand := func(operator string) {
 			Convey("should error when stack only has one operand", func() {
@@ -28,7 +28,7 @@ func TestInterpreter(t *testing.T) {
 				So(mockOutput, ShouldExpectOutputErrors, ErrEmptyStack{})
 				Convey("error should not alter stack", func() {
 					So(mockInterpreter.StackDepth(), ShouldEqual, 1)
-					So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number,
+					So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number.Float64(),
 						ShouldAlmostEqual, 1)
 				})
 			})
@@ -49,20 +49,20 @@ func TestInterpreter(t *testing.T) {
 
 		Convey("Stack Operations", func() {
 			Convey("StackClear() should empty stack", func() {
-				mockInterpreter.StackPush(quantity.Q{Number: 1})
-				mockInterpreter.StackPush(quantity.Q{Number: 2})
+				mockInterpreter.StackPush(quantity.Q{Number: quantity.Float(1)})
+				mockInterpreter.StackPush(quantity.Q{Number: quantity.Float(2)})
 				So(mockInterpreter.StackDepth(), ShouldEqual, 2)
 				mockInterpreter.StackClear()
 				So(mockInterpreter.StackDepth(), ShouldEqual, 0)
 			})
 			Convey("Empty Stack should return error", func() {
-				mockInterpreter.StackPush(quantity.Q{Number: 1})
-				mockInterpreter.StackPush(quantity.Q{Number: 2})
+				mockInterpreter.StackPush(quantity.Q{Number: quantity.Float(1)})
+				mockInterpreter.StackPush(quantity.Q{Number: quantity.Float(2)})
 				v1, err1 := mockInterpreter.StackPop()
 				v2, err2 := mockInterpreter.StackPop()
 				v3, err3 := mockInterpreter.StackPop()
-				So(v1.Number, ShouldAlmostEqual, 2)
-				So(v2.Number, ShouldAlmostEqual, 1)
+				So(v1.Number.Float64(), ShouldAlmostEqual, 2)
+				So(v2.Number.Float64(), ShouldAlmostEqual, 1)
 				So(v3, ShouldBeNil)
 				So(err1, ShouldBeNil)
 				So(err2, ShouldBeNil)
@@ -70,13 +70,13 @@ func TestInterpreter(t *testing.T) {
 			})
 			Convey("Full stack should leak", func() {
 				for i := 0; i <= MaxStackDepth; i++ {
-					mockInterpreter.StackPush(quantity.Q{Number: float64(i)})
+					mockInterpreter.StackPush(quantity.Q{Number: quantity.Float(i)})
 				}
 				So(mockInterpreter.StackDepth(), ShouldEqual, MaxStackDepth)
 				for i := MaxStackDepth; i > 0; i-- {
 					v, err := mockInterpreter.StackPop()
 					So(err, ShouldBeNil)
-					So(v.Number, ShouldAlmostEqual, float64(i))
+					So(v.Number.Float64(), ShouldAlmostEqual, float64(i))
 				}
 				v, err := mockInterpreter.StackPop()
 				So(err, ShouldBeError, ErrEmptyStack{})
@@ -109,7 +109,7 @@ func TestInterpreter(t *testing.T) {
 							So(mockInterpreter.StackDepth(), ShouldEqual, 3)
 						})
 						Convey("Should output stack top", func() {
-							So(mockOutput, ShouldExpectOutputQuantities, quantity.Q{Number: .25}, quantity.Q{Number: .25})
+							So(mockOutput, ShouldExpectOutputQuantities, quantity.Q{Number: quantity.Float(.25)}, quantity.Q{Number: quantity.Float(.25)})
 						})
 						testErrorOnNoOperands("p")
 					})
@@ -127,8 +127,8 @@ func TestInterpreter(t *testing.T) {
 						})
 						Convey("Should output stack top", func() {
 							So(mockOutput, ShouldExpectOutputQuantities,
-								quantity.Q{Number: .25},
-								quantity.Q{Number: 1.5})
+								quantity.Q{Number: quantity.Float(.25)},
+								quantity.Q{Number: quantity.Float(1.5)})
 						})
 						testErrorOnNoOperands("n")
 					})
@@ -146,12 +146,12 @@ func TestInterpreter(t *testing.T) {
 						})
 						Convey("Should output full stack", func() {
 							So(mockOutput, ShouldExpectOutputQuantities,
-								quantity.Q{Number: 1},
-								quantity.Q{Number: 1.5},
-								quantity.Q{Number: .25},
-								quantity.Q{Number: 1},
-								quantity.Q{Number: 1.5},
-								quantity.Q{Number: .25},
+								quantity.Q{Number: quantity.Float(1)},
+								quantity.Q{Number: quantity.Float(1.5)},
+								quantity.Q{Number: quantity.Float(.25)},
+								quantity.Q{Number: quantity.Float(1)},
+								quantity.Q{Number: quantity.Float(1.5)},
+								quantity.Q{Number: quantity.Float(.25)},
 							)
 						})
 						Convey("Should be no-op on empty stack", func() {
@@ -175,12 +175,12 @@ func TestInterpreter(t *testing.T) {
 							So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
 							So(mockInterpreter.StackDepth(), ShouldEqual, 3)
 							So(mockOutput, ShouldExpectOutputErrors)
-							So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number,
+							So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number.Float64(),
 								ShouldAlmostEqual,
 								.25,
 							)
 							So(mockInterpreter.Stack[mockInterpreter.StackPointer].UnitExponents, ShouldResemble,
-								quantity.UCombination{{Unit: quantity.UnitLiter, Exponent: 1}})
+								quantity.UCombination{{Unit: quantity.UnitLiter, Exponent: quantity.IntExponent(1)}})
 						})
 					})
 					Convey("Operator +", func() {
@@ -191,7 +191,7 @@ func TestInterpreter(t *testing.T) {
 							So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
 							So(mockInterpreter.StackDepth(), ShouldEqual, 2)
 							So(mockOutput, ShouldExpectOutputErrors)
-							So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number,
+							So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number.Float64(),
 								ShouldAlmostEqual,
 								1.5+.25,
 							)
@@ -205,3 +205,612 @@ func TestInterpreter(t *testing.T) {
 		})
 	})
 }
+
+func TestOperatorV(t *testing.T) {
+	Convey("Operator v", t, func() {
+		mockInput, mockOutput, mockInterpreter, _ := newMockedState()
+
+		Convey("should take square root of odd exponents", func() {
+			mockInput.inputTokens = []syntax.Token{
+				&syntax.TokenNumeric{Literal: "4"},
+				&syntax.TokenUnit{Literal: "(m)"},
+				&syntax.TokenNumeric{Literal: "1"},
+				&syntax.TokenUnit{Literal: "(s)"},
+				&syntax.TokenOperator{Literal: "/"},
+				&syntax.TokenOperator{Literal: "v"},
+			}
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockInterpreter.StackDepth(), ShouldEqual, 1)
+			So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number.Float64(), ShouldAlmostEqual, 2)
+			So(mockInterpreter.Stack[mockInterpreter.StackPointer].UnitExponents, ShouldResemble,
+				quantity.UCombination{
+					{Unit: quantity.UnitMeter, Exponent: quantity.NewExponent(1, 2)},
+					{Unit: quantity.UnitSecond, Exponent: quantity.NewExponent(-1, 2)},
+				})
+		})
+	})
+}
+
+func TestBigFloatMode(t *testing.T) {
+	Convey("Big float mode", t, func() {
+		mockInput, mockOutput, mockInterpreter, _ := newMockedState()
+		mockInterpreter.Numbers = quantity.NumberContext{Mode: quantity.NumberModeBigFloat}
+
+		Convey("should keep precision through operators and unit conversions", func() {
+			mockInput.tokenize("0.1 (ml) 0.2 (ml) + 3 / 3 * (ul) p")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, _ := mockOutput.outputQuantities[0].Format()
+			So(num.String(), ShouldEqual, "300")
+		})
+		Convey("should keep precision of unit conversions with offsets", func() {
+			mockInput.tokenize("1 (degF) (K) p")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, _ := mockOutput.outputQuantities[0].Format()
+			So(num.String(), ShouldStartWith, "255.9277777777777777777777777777777777777777777777777777777777777777777777")
+		})
+	})
+}
+
+func TestRationalMode(t *testing.T) {
+	Convey("Rational mode", t, func() {
+		mockInput, mockOutput, mockInterpreter, _ := newMockedState()
+		mockInterpreter.Numbers = quantity.NumberContext{Mode: quantity.NumberModeRational}
+
+		Convey("serial dilutions should stay exact", func() {
+			mockInput.tokenize("1 (M) 3 / 3 / 3 / (uM) p")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, _ := mockOutput.outputQuantities[0].Format()
+			So(quantity.FormatNumber(num, true), ShouldEqual, "1000000/27")
+			So(mockOutput.outputWarnings, ShouldBeEmpty)
+		})
+		Convey("temperatures should convert exactly", func() {
+			mockInput.tokenize("1 (degF) (K) p")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, _ := mockOutput.outputQuantities[0].Format()
+			So(quantity.FormatNumber(num, true), ShouldEqual, "46067/180")
+			So(mockOutput.outputWarnings, ShouldBeEmpty)
+		})
+		Convey("division by zero should warn", func() {
+			mockInput.tokenize("1 0 /")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockOutput.outputWarnings, ShouldHaveLength, 1)
+			So(mockOutput.outputWarnings[0], ShouldHaveSameTypeAs, WarnInexact{})
+			So(mockInterpreter.StackDepth(), ShouldEqual, 1)
+		})
+		Convey("square roots of non-squares should warn", func() {
+			mockInput.tokenize("2 v 4 v")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockOutput.outputWarnings, ShouldHaveLength, 1)
+			So(mockOutput.outputWarnings[0], ShouldHaveSameTypeAs, WarnInexact{})
+			So(mockInterpreter.StackDepth(), ShouldEqual, 2)
+		})
+	})
+}
+
+func TestUncertainty(t *testing.T) {
+	Convey("Uncertainty", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+
+		Convey("should be scaled by units", func() {
+			mockInput.tokenize("1.50±0.02 (mg)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Uncertainty, ShouldAlmostEqual, 2e-5)
+			So(top().Display().NumberString(false), ShouldEqual, "1.500±0.020")
+		})
+		Convey("should propagate through operators", func() {
+			mockInput.tokenize("3+/-0.3 (mg) 4+/-0.4 (mg) + 0.05+/-0.004 (ml) / v")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			// sqrt(7 ± 0.5 mg / (0.05 ± 0.004 ml)), relative uncertainties add in quadrature and halve
+			relative := math.Hypot(0.5/7, 0.004/0.05) / 2
+			So(top().Number.Float64(), ShouldAlmostEqual, math.Sqrt(140))
+			So(top().Uncertainty, ShouldAlmostEqual, math.Sqrt(140)*relative)
+		})
+		Convey("exact quantities should stay exact", func() {
+			mockInput.tokenize("3 (mg) 4 (mg) * v")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(top().Uncertainty, ShouldEqual, 0)
+		})
+	})
+}
+
+func TestExplicitUnits(t *testing.T) {
+	Convey("Explicit units", t, func() {
+		mockInput, _, mockInterpreter, top := newMockedState()
+		opts := mockInterpreter.Registry.FormatOptions(false, true)
+		display := func(q quantity.Q) (float64, string) {
+			d := q.DisplayWith(opts)
+			return d.Number.Float64(), d.Units[0].Identifier
+		}
+
+		Convey("should not be set for numbers given units", func() {
+			mockInput.tokenize("125000 (nl)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(top().ExplicitUnits, ShouldBeFalse)
+			num, unit := display(top())
+			So(num, ShouldAlmostEqual, 125)
+			So(unit, ShouldEqual, "µl")
+
+			mockInput.tokenize("0.000025 (g)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			num, unit = display(top())
+			So(num, ShouldAlmostEqual, 25)
+			So(unit, ShouldEqual, "µg")
+		})
+		Convey("should be kept when prefixing automatically", func() {
+			mockInput.tokenize("125000 (nl) (nl)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(top().ExplicitUnits, ShouldBeTrue)
+			num, unit := display(top())
+			So(num, ShouldAlmostEqual, 125000)
+			So(unit, ShouldEqual, "nl")
+		})
+		Convey("should be cleared by arithmetic", func() {
+			mockInput.tokenize("125000 (nl) 2 *")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(top().ExplicitUnits, ShouldBeFalse)
+			num, unit := display(top())
+			So(num, ShouldAlmostEqual, 250)
+			So(unit, ShouldEqual, "µl")
+		})
+	})
+}
+
+func TestSubstanceIU(t *testing.T) {
+	Convey("Substance IU", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+
+		Convey("should convert to and from mass", func() {
+			mockInput.tokenize("400 (iu:vitD3) (ug)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 1e-5)
+			So(top().UnitExponents.String(), ShouldEqual, "(g)")
+
+			mockInput.tokenize("(miu:vitD3)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 400)
+			So(top().UnitExponents.String(), ShouldEqual, "(iu:vitD3)")
+		})
+		Convey("should not convert between substances", func() {
+			mockInput.tokenize("400 (iu:vitD3) (iu:vitD2)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, quantity.ErrSubstanceMismatch{})
+			So(top().Number.Float64(), ShouldAlmostEqual, 400)
+		})
+	})
+}
+
+func TestMolarMass(t *testing.T) {
+	Convey("Molar mass of chemical formulas", t, func() {
+		mockInput, mockOutput, mockInterpreter, _ := newMockedState()
+
+		Convey("should push the molar mass in Da", func() {
+			mockInput.tokenize(`"NaCl" mw n`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockOutput, ShouldExpectOutputQuantities, quantity.Q{
+				Number:            quantity.Float(58.44),
+				UnitExponents:     quantity.UnitDerivedAmu.UnitExponents,
+				DerivedUnitsToUse: quantity.UDerivedList{quantity.UnitDerivedAmu},
+			})
+		})
+		Convey("should convert mass into molar concentrations", func() {
+			mockInput.tokenize(`5.844 (mg) 1 (ml) / "NaCl" mw mwconv n`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockOutput, ShouldExpectOutputQuantities, quantity.Q{
+				Number:            quantity.Float(0.1),
+				UnitExponents:     quantity.UnitDerivedMolar.UnitExponents,
+				DerivedUnitsToUse: quantity.UDerivedList{quantity.UnitDerivedMolar},
+			})
+		})
+		Convey("should refuse quantities that are not molar masses", func() {
+			mockInput.tokenize(`1 (mol) 1 (l) mwconv`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+			So(mockInterpreter.StackPointer, ShouldEqual, 1)
+		})
+		Convey("should keep the formula on errors", func() {
+			mockInput.tokenize(`"Ca(OH2" mw`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, chem.ErrUnclosedGroup{})
+			So(mockInterpreter.Strings, ShouldResemble, []string{"Ca(OH2"})
+		})
+	})
+}
+
+func TestDilution(t *testing.T) {
+	Convey("Dilution operators", t, func() {
+		mockInput, mockOutput, mockInterpreter, _ := newMockedState()
+
+		liter := quantity.UCombination{{Unit: quantity.UnitLiter, Exponent: quantity.IntExponent(1)}}
+		_, milliliter := mockInterpreter.Registry.Lookup("ml")
+		_, microliter := mockInterpreter.Registry.Lookup("ul")
+		_, millimolar := mockInterpreter.Registry.Lookup("mM")
+
+		Convey("dil should compute the volumes of stock and diluent", func() {
+			mockInput.tokenize("100 (mM) 50 (uM) 10 (ml) dil f")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockOutput, ShouldExpectOutputQuantities,
+				quantity.Q{Number: quantity.Float(5e-6), UnitExponents: liter, DerivedUnitsToUse: quantity.UDerivedList{*milliliter}},
+				quantity.Q{Number: quantity.Float(9.995e-3), UnitExponents: liter, DerivedUnitsToUse: quantity.UDerivedList{*milliliter}},
+			)
+		})
+		Convey("dilv should compute the final volume and diluent", func() {
+			mockInput.tokenize("100 (mM) 5 (ul) 50 (uM) dilv f")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockOutput, ShouldExpectOutputQuantities,
+				quantity.Q{Number: quantity.Float(1e-2), UnitExponents: liter, DerivedUnitsToUse: quantity.UDerivedList{*microliter}},
+				quantity.Q{Number: quantity.Float(9.995e-3), UnitExponents: liter, DerivedUnitsToUse: quantity.UDerivedList{*microliter}},
+			)
+		})
+		Convey("dilc should compute the final concentration", func() {
+			mockInput.tokenize("100 (mM) 5 (ul) 10 (ml) dilc n")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockInterpreter.StackPointer, ShouldEqual, -1)
+			So(mockOutput.outputQuantities[0].Number.Float64(), ShouldAlmostEqual, 5e-5)
+			So(mockOutput.outputQuantities[0].DerivedUnitsToUse, ShouldResemble, quantity.UDerivedList{*millimolar})
+		})
+		Convey("concentrations should be of the same kind", func() {
+			mockInput.tokenize("1 (mg) 1 (ml) / 50 (uM) 10 (ml) dil")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+			So(mockInterpreter.StackPointer, ShouldEqual, 2)
+		})
+		Convey("final concentrations can not exceed the stock", func() {
+			mockInput.tokenize("50 (uM) 100 (mM) 10 (ml) dil")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrImpossibleDilution{})
+		})
+		Convey("uncertainties should propagate", func() {
+			mockInput.tokenize("100±1 (mM) 50 (uM) 10 (ml) dil")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			stock := mockInterpreter.Stack[mockInterpreter.StackPointer-1]
+			So(stock.Uncertainty, ShouldAlmostEqual, 5e-8)
+		})
+	})
+}
+
+func TestConst(t *testing.T) {
+	Convey("const", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+
+		Convey("should push constants with their units", func() {
+			// the thermal voltage RT/F at 25 °C
+			mockInput.tokenize(`"R" const 298.15 (K) * "F" const / (mV)`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 25.693e-3*1e3, 1e-3)
+			num, units := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 25.693, 1e-3)
+			So(units, ShouldResemble, quantity.UnitDisplayList{{Identifier: "mV", Exponent: quantity.IntExponent(1)}})
+		})
+		Convey("should keep the name of unknown constants", func() {
+			mockInput.tokenize(`"Na" const`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, quantity.ErrUnknownConstant{})
+			So(mockInterpreter.Strings, ShouldResemble, []string{"Na"})
+		})
+	})
+}
+
+func TestDoses(t *testing.T) {
+	Convey("Dose operators", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+		area := quantity.UCombination{{Unit: quantity.UnitMeter, Exponent: quantity.IntExponent(2)}}
+
+		Convey("dose should multiply doses by body weight", func() {
+			mockInput.tokenize("5 (mg) 1 (kg) / 70 (kg) dose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 0.35)
+			num, units := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 350)
+			So(units, ShouldResemble, quantity.UnitDisplayList{{Identifier: "mg", Exponent: quantity.IntExponent(1)}})
+
+			mockInput.tokenize("c 70 (kg) 1 (ml) 1 (kg) / 1 (h) / dose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 0.07/3600)
+
+			mockInput.tokenize("c 5 (mg) 1 (kg) / 1 (h) / 70 (kg) dose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 0.35/3600)
+		})
+		Convey("dose should refuse doses not per body weight", func() {
+			mockInput.tokenize("5 (mg) 70 (kg) dose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+			So(mockInterpreter.StackPointer, ShouldEqual, 1)
+
+			mockInput.tokenize("c 5 70 (kg) dose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+
+			mockInput.tokenize("c 5 (mg) 1 (kg) / 70 (ml) dose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+
+			for _, dose := range []string{"5 (ml)", "5 (iu:insulin)", "5 (ml) 1 (g) *", "5 (%)"} {
+				mockInput.tokenize("c " + dose + " 70 (kg) dose")
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+			}
+		})
+		Convey("bsa should estimate body surface areas", func() {
+			for formula, expected := range map[string]float64{
+				"bsa":        1.8708,
+				"bsadubois":  1.8863,
+				"bsahaycock": 1.8675,
+			} {
+				mockInput.tokenize("c 180 (cm) 70 (kg) " + formula)
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors)
+				So(top().Number.Float64(), ShouldAlmostEqual, expected, 1e-4)
+				So(top().UnitExponents, ShouldResemble, area)
+			}
+			mockInput.tokenize("c 70 (kg) 1.8 (m) bsa")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(top().Number.Float64(), ShouldAlmostEqual, 1.8708, 1e-4)
+		})
+		Convey("bsa should refuse other quantities", func() {
+			mockInput.tokenize("180 (cm) 70 (l) bsa")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+			So(mockInterpreter.StackPointer, ShouldEqual, 1)
+
+			mockInput.tokenize("c 0 (cm) 70 (kg) bsa")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrDomain{})
+		})
+		Convey("bsadose should multiply doses by body surface area", func() {
+			mockInput.tokenize("75 (mg) 1 (m) 1 (m) * / 180 (cm) 70 (kg) bsa bsadose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 0.075*1.8708, 1e-4)
+
+			mockInput.tokenize("c 5 (mg) 1 (kg) / 180 (cm) 70 (kg) bsa bsadose")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+		})
+	})
+}
+
+func TestRates(t *testing.T) {
+	Convey("Rates", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+		display := func(q quantity.Q) (float64, quantity.UnitDisplayList) {
+			num, units := q.Format()
+			return num.Float64(), units
+		}
+
+		Convey("should convert flow rates", func() {
+			mockInput.tokenize("1 (ml) 1 (h) / (ul) (min)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, units := display(top())
+			So(num, ShouldAlmostEqual, 1000./60)
+			So(units, ShouldResemble, quantity.UnitDisplayList{
+				{Identifier: "ul", Exponent: quantity.IntExponent(1)},
+				{Identifier: "min", Exponent: quantity.IntExponent(-1)},
+			})
+		})
+		Convey("should compute dose rates", func() {
+			mockInput.tokenize("5 (mg) 1 (ml) / 10 (ml) 1 (h) / *")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, units := display(top())
+			So(num, ShouldAlmostEqual, 50)
+			So(units, ShouldResemble, quantity.UnitDisplayList{
+				{Identifier: "mg", Exponent: quantity.IntExponent(1)},
+				{Identifier: "h", Exponent: quantity.IntExponent(-1)},
+			})
+
+			mockInput.tokenize("0.5 (ug) 1 (kg) / 1 (min) / 70 (kg) * (mg) (day)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, units = display(top())
+			So(num, ShouldAlmostEqual, 50.4)
+			So(units, ShouldResemble, quantity.UnitDisplayList{
+				{Identifier: "mg", Exponent: quantity.IntExponent(1)},
+				{Identifier: "day", Exponent: quantity.IntExponent(-1)},
+			})
+		})
+	})
+}
+
+func TestRatioUnits(t *testing.T) {
+	Convey("Ratio units", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+		display := func(q quantity.Q) (float64, quantity.UnitDisplayList) {
+			num, units := q.Format()
+			return num.Float64(), units
+		}
+
+		Convey("should scale bare numbers", func() {
+			mockInput.tokenize("5 (%)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 0.05)
+			num, units := display(top())
+			So(num, ShouldAlmostEqual, 5)
+			So(units, ShouldResemble, quantity.UnitDisplayList{{Identifier: "%", Exponent: quantity.IntExponent(1)}})
+		})
+		Convey("should still scale bare numbers after a failed conversion", func() {
+			mockInput.tokenize("5 (nosuchunit)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrUnknownUnit{})
+			So(top().Bare, ShouldBeTrue)
+
+			mockInput.tokenize("(%)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 0.05)
+			So(top().Bare, ShouldBeFalse)
+		})
+		Convey("should convert between each other", func() {
+			mockInput.tokenize("5 (%) (ppm)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			num, units := display(top())
+			So(num, ShouldAlmostEqual, 50000)
+			So(units, ShouldResemble, quantity.UnitDisplayList{{Identifier: "ppm", Exponent: quantity.IntExponent(1)}})
+
+			mockInput.tokenize("(1)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			num, units = display(top())
+			So(num, ShouldAlmostEqual, 0.05)
+			So(units, ShouldBeEmpty)
+		})
+		Convey("should display computed ratios", func() {
+			mockInput.tokenize("1 (mg) 20 (g) / (ppm)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 5e-5)
+			num, _ := display(top())
+			So(num, ShouldAlmostEqual, 50)
+		})
+		Convey("should refuse quantities with units", func() {
+			mockInput.tokenize("1 (l) (%)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+		})
+		Convey("% w/v should convert to mass and molar concentrations", func() {
+			mockInput.tokenize(`0.9 (%w/v) (mg) (ml)`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			num, _ := display(top())
+			So(num, ShouldAlmostEqual, 9)
+
+			mockInput.tokenize(`"NaCl" mw mwconv (mM)`)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, _ = display(top())
+			So(num, ShouldAlmostEqual, 154.004, 0.001)
+		})
+	})
+}
+
+func TestLogarithms(t *testing.T) {
+	Convey("Logarithms", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+
+		Convey("operators should require dimensionless quantities", func() {
+			mockInput.tokenize("100 log10 ln exp pow10")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 100)
+
+			mockInput.tokenize("c 1 (l) ln")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+
+			mockInput.tokenize("c 0 log10")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrDomain{})
+		})
+		Convey("should propagate uncertainties", func() {
+			mockInput.tokenize("100±1 log10")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(top().Uncertainty, ShouldAlmostEqual, 0.01/math.Ln10)
+		})
+		Convey("pH should be a concentration", func() {
+			mockInput.tokenize("7 (pH)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 1e-7)
+			unit := top().UnitExponents
+			So(unit.Equal(&quantity.UnitDerivedMolar.UnitExponents), ShouldBeTrue)
+			num, _ := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 7)
+		})
+		Convey("should solve the Henderson–Hasselbalch equation", func() {
+			mockInput.tokenize("4.76 (pKa) 5 (pH) / log10")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 0.24)
+		})
+		Convey("pH should only display concentrations", func() {
+			mockInput.tokenize("1 (l) (pH)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+		})
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	Convey("Printed quantities", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+		roundTrip := func(source string, fraction bool) {
+			mockInput.tokenize("c " + source)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			printed := top()
+			str := printed.Display().String(fraction)
+
+			mockInput.tokenize("c " + str)
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Display().Units, ShouldResemble, printed.Display().Units)
+			if x := printed.Number.Float64(); math.IsNaN(x) {
+				So(math.IsNaN(top().Number.Float64()), ShouldBeTrue)
+			} else {
+				So(top().Number.Float64(), ShouldEqual, x)
+			}
+			So(top().Uncertainty, ShouldAlmostEqual, printed.Uncertainty, printed.Uncertainty*1e-1)
+			comb := top().UnitExponents
+			So(comb.Equal(&printed.UnitExponents), ShouldBeTrue)
+		}
+
+		Convey("should give the same quantity when entered again", func() {
+			for _, source := range []string{
+				"1 (ml) 1 (h) / (ul) (min)",
+				"5 (mg) 1 (ml) /",
+				`"R" const`,
+				`"me" const`,
+				"1.5±0.02 (mg)",
+				"1e-9 (g)",
+				"20 (degC)",
+				"5 (Δ°C)",
+				"7.4 (pH)",
+				"5 (%)",
+				"10 (ug) (iu:vitD3)",
+				"2 (Hz^(1/2))",
+				"60 (1/min)",
+				"20 (degC) (degF)",
+				"0.1 (mg) (ug)",
+				"3 (mm) 3 (mm) * (cm^2)",
+				"1 0 /",
+				"-1 0 / (g)",
+				"0 0 /",
+			} {
+				roundTrip(source, false)
+			}
+		})
+		Convey("should not show rounding errors of unit conversions", func() {
+			mockInput.tokenize("c 20 (degC) (degF) 5 (ug) 1 (ml) /")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockInterpreter.Stack[0].Display().String(false), ShouldEqual, "68 (degF)")
+			So(top().Display().String(false), ShouldEqual, "5 (ug/ml)")
+		})
+		Convey("should keep exact fractions", func() {
+			mockInterpreter.Numbers = quantity.NumberContext{Mode: quantity.NumberModeRational}
+			roundTrip("1 3 / (g)", true)
+			So(quantity.IsExact(top().Number), ShouldBeTrue)
+			So(top().Display().String(true), ShouldEqual, "1/3 (g)")
+		})
+	})
+}
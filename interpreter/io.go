@@ -13,3 +13,32 @@ type IOutput interface {
 	PrintQuantity(values []quantity.Q) (err error)
 	PrintError(err error) error
 }
+
+// IWarningOutput is implemented by outputs that can show warnings.
+//
+// Unlike errors, warnings do not interrupt evaluation. Warnings are printed as errors
+// if the output does not implement this interface.
+type IWarningOutput interface {
+	PrintWarning(warning error) error
+}
+
+func (s *State) warn(warning error) error {
+	if output, ok := s.Output.(IWarningOutput); ok {
+		return output.PrintWarning(warning)
+	}
+	return s.Output.PrintError(warning)
+}
+
+// IDefinitionOutput is implemented by outputs that can save the units defined during a session.
+//
+// SaveDefinitions is called with all units defined so far whenever a new unit is defined.
+type IDefinitionOutput interface {
+	SaveDefinitions(defs quantity.Definitions) error
+}
+
+func (s *State) saveDefinitions() error {
+	if output, ok := s.Output.(IDefinitionOutput); ok {
+		return output.SaveDefinitions(s.Defined)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package interpreter
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"math"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/eternal-flame-ad/unitdc/quantity"
 	"github.com/eternal-flame-ad/unitdc/syntax"
+	"github.com/eternal-flame-ad/unitdc/tokenizer"
 )
 
 type MockedInterpreterInput struct {
@@ -24,9 +26,32 @@ func (i *MockedInterpreterInput) ReadToken() (tok syntax.Token, err error) {
 	return
 }
 
+// tokenize sets the remaining input tokens to the tokens in source
+func (i *MockedInterpreterInput) tokenize(source string) {
+	tokens, err := tokenizer.ParseTokenUntilEOF(bytes.NewBufferString(source))
+	if err != nil {
+		panic(err)
+	}
+	i.inputTokens = tokens
+}
+
+// newMockedState creates a default state reading from and writing to mocks,
+// and a function returning the quantity on the top of its stack
+func newMockedState() (*MockedInterpreterInput, *MockedInterpreterOutput, *State, func() quantity.Q) {
+	mockInput := new(MockedInterpreterInput)
+	mockOutput := new(MockedInterpreterOutput)
+	mockInterpreter := NewDefaultState(mockInput, mockOutput)
+	top := func() quantity.Q {
+		return mockInterpreter.Stack[mockInterpreter.StackPointer]
+	}
+	return mockInput, mockOutput, mockInterpreter, top
+}
+
 type MockedInterpreterOutput struct {
 	outputErrors     []error
+	outputWarnings   []error
 	outputQuantities []quantity.Q
+	savedDefinitions *quantity.Definitions
 }
 
 func (o *MockedInterpreterOutput) PrintQuantity(values []quantity.Q) (err error) {
@@ -39,6 +64,16 @@ func (o *MockedInterpreterOutput) PrintError(err error) error {
 	return nil
 }
 
+func (o *MockedInterpreterOutput) PrintWarning(warning error) error {
+	o.outputWarnings = append(o.outputWarnings, warning)
+	return nil
+}
+
+func (o *MockedInterpreterOutput) SaveDefinitions(defs quantity.Definitions) error {
+	o.savedDefinitions = &defs
+	return nil
+}
+
 func ShouldExpectOutputQuantities(output interface{}, expected ...interface{}) string {
 	out := output.(*MockedInterpreterOutput)
 	if len(out.outputQuantities) != len(expected) {
@@ -47,7 +82,7 @@ func ShouldExpectOutputQuantities(output interface{}, expected ...interface{}) s
 		for i, e := range expected {
 			actual := out.outputQuantities[i]
 			expect := e.(quantity.Q)
-			if math.Abs(expect.Number-actual.Number) > .001 ||
+			if math.Abs(expect.Number.Float64()-actual.Number.Float64()) > .001 ||
 				!expect.UnitExponents.Equal(&actual.UnitExponents) ||
 				!quantity.EqualUDerivedLists(expect.DerivedUnitsToUse, actual.DerivedUnitsToUse) {
 				return fmt.Sprintf("[error #%d]: expected quantity %#v, got %#v", i, expect, actual)
@@ -1,19 +1,65 @@
 package interpreter
 
 import (
+	"math/big"
+
 	"github.com/eternal-flame-ad/unitdc/quantity"
 	"github.com/eternal-flame-ad/unitdc/syntax"
 )
 
-// LiteralNumber pushes the number onto the current stack as a unitless quantity.
+// LiteralNumber pushes the number onto the current stack as a unitless quantity,
+// in the number representation of the current session.
+//
+// The literal may carry a standard uncertainty, like 1.50±0.02.
+// Infinities and NaN are always float64 numbers in rational mode.
 func (s *State) LiteralNumber(numTok syntax.TokenNumeric) (err error) {
-	var num float64
-	num, err = numTok.Float()
+	var uncertainty float64
+	uncertainty, err = numTok.Uncertainty()
+	if err != nil {
+		return
+	}
+
+	if f, ok := numTok.Special(); ok {
+		s.StackPush(quantity.Q{
+			Number:      s.Numbers.FromFloat64(f),
+			Uncertainty: uncertainty,
+			Bare:        true,
+		})
+		return
+	}
+
+	if s.Numbers.Mode == quantity.NumberModeRational {
+		num := new(big.Rat)
+		err = numTok.Rat(num)
+		if err != nil {
+			return
+		}
+		s.StackPush(quantity.Q{
+			Number:      s.Numbers.FromRat(num),
+			Uncertainty: uncertainty,
+			Bare:        true,
+		})
+		return
+	}
+
+	num := new(big.Float).SetPrec(s.Numbers.FloatPrecision())
+	err = numTok.BigFloat(num)
 	if err != nil {
 		return
 	}
 	s.StackPush(quantity.Q{
-		Number: num,
+		Number:      s.Numbers.FromBigFloat(num),
+		Uncertainty: uncertainty,
+		Bare:        true,
 	})
 	return
 }
+
+// checkExact warns if an operation turned an exact operand into an inexact result
+func (s *State) checkExact(operation string, operand quantity.Number, res quantity.Number) error {
+	if s.Numbers.Mode == quantity.NumberModeRational &&
+		quantity.IsExact(operand) && !quantity.IsExact(res) {
+		return s.warn(WarnInexact{Operation: operation})
+	}
+	return nil
+}
@@ -4,8 +4,10 @@ import "github.com/eternal-flame-ad/unitdc/quantity"
 
 // OperatorPlus pops two quantities from the stack, adds them together
 //
-// operands must be of equal unit
-// result is the same unit as the operand
+// uncertainties of the operands are assumed to be uncorrelated and added in quadrature
+//
+// operands must be of equal unit, and may not both be absolute temperatures
+// result is the same unit as the operand, a temperature in kelvin plus a temperature difference is in kelvin
 func (s *State) OperatorPlus() (err error) {
 	var operand1, operand2 *quantity.Q
 	operand2, err = s.StackPop()
@@ -32,11 +34,26 @@ func (s *State) OperatorPlus() (err error) {
 		return
 	}
 
+	scale, ok := quantity.AddScales(operand1.Scale, operand2.Scale)
+	if !ok {
+		err = ErrAbsoluteScale{OffendingUnit: operand2.UnitExponents}
+		return
+	}
+
 	res := quantity.Q{
-		Number:            operand1.Number + operand2.Number,
+		Number:            operand1.Value().Add(operand2.Value()),
+		Uncertainty:       quantity.SumUncertainty(operand1.Uncertainty, operand2.Uncertainty),
 		UnitExponents:     operand1.UnitExponents,
+		Scale:             scale,
 		DerivedUnitsToUse: quantity.CombineUDerivedLists(operand1.DerivedUnitsToUse, operand2.DerivedUnitsToUse),
 	}
+	switch {
+	// like 300 K + 5 Δ°C, the sum is in the units of the temperature in kelvin
+	case operand1.Scale == quantity.ScaleRatio && operand2.Scale == quantity.ScaleInterval:
+		res.DerivedUnitsToUse = operand1.DerivedUnitsToUse
+	case operand1.Scale == quantity.ScaleInterval && operand2.Scale == quantity.ScaleRatio:
+		res.DerivedUnitsToUse = operand2.DerivedUnitsToUse
+	}
 	if operand1.UnitExponents.IsNoUnit() {
 		res.UnitExponents = operand2.UnitExponents
 	}
@@ -49,7 +66,8 @@ func (s *State) OperatorPlus() (err error) {
 // and push the result onto the stack
 //
 // operands must be of equal unit
-// result is the same unit as the operand
+// result is the same unit as the operand, the difference of two absolute temperatures is a temperature difference
+// also when the first one is in kelvin
 func (s *State) OperatorMinus() (err error) {
 	var operand1, operand2 *quantity.Q
 	operand2, err = s.StackPop()
@@ -76,11 +94,23 @@ func (s *State) OperatorMinus() (err error) {
 		return
 	}
 
+	scale, ok := quantity.SubScales(operand1.Scale, operand2.Scale)
+	if !ok {
+		err = ErrAbsoluteScale{OffendingUnit: operand2.UnitExponents}
+		return
+	}
+
 	res := quantity.Q{
-		Number:            operand1.Number - operand2.Number,
+		Number:            operand1.Value().Sub(operand2.Value()),
+		Uncertainty:       quantity.SumUncertainty(operand1.Uncertainty, operand2.Uncertainty),
 		UnitExponents:     operand1.UnitExponents,
+		Scale:             scale,
 		DerivedUnitsToUse: quantity.CombineUDerivedLists(operand1.DerivedUnitsToUse, operand2.DerivedUnitsToUse),
 	}
+	if operand1.Scale == quantity.ScaleRatio && operand2.Scale != quantity.ScaleRatio {
+		// like 300 K - 20 °C or 300 K - 5 Δ°C, the difference is in the units of the temperature in kelvin
+		res.DerivedUnitsToUse = operand1.DerivedUnitsToUse
+	}
 	if operand1.UnitExponents.IsNoUnit() {
 		res.UnitExponents = operand2.UnitExponents
 	}
@@ -92,7 +122,8 @@ func (s *State) OperatorMinus() (err error) {
 // OperatorMultiply pops two quantities from the stack, multiplies the top
 // and push the result onto the stack
 //
-// unit will be handled accordingly
+// unit will be handled accordingly, uncertainties are propagated to first order
+// absolute temperatures may only be multiplied with unitless quantities, convert them to (K) first
 func (s *State) OperatorMultiply() (err error) {
 	var operand1, operand2 *quantity.Q
 	operand2, err = s.StackPop()
@@ -114,9 +145,19 @@ func (s *State) OperatorMultiply() (err error) {
 		}
 	}()
 
+	scale, ok := quantity.MulScales(*operand1, *operand2)
+	if !ok {
+		err = ErrAbsoluteScale{OffendingUnit: absoluteUnits(*operand1, *operand2)}
+		return
+	}
+
 	res := quantity.Q{
-		Number:            operand1.Number * operand2.Number,
+		Number: operand1.Value().Mul(operand2.Value()),
+		Uncertainty: quantity.ProductUncertainty(
+			operand1.Value().Float64(), operand1.Uncertainty,
+			operand2.Value().Float64(), operand2.Uncertainty),
 		UnitExponents:     append(operand1.UnitExponents, operand2.UnitExponents...),
+		Scale:             scale,
 		DerivedUnitsToUse: quantity.CombineUDerivedLists(operand1.DerivedUnitsToUse, operand2.DerivedUnitsToUse),
 	}
 	res.UnitExponents.Simplify()
@@ -127,7 +168,10 @@ func (s *State) OperatorMultiply() (err error) {
 // OperatorDivide pops two quantities from the stack, divides them
 // and push the result onto the stack
 //
-// unit will be handled accordingly
+// unit will be handled accordingly, uncertainties are propagated to first order
+// absolute temperatures may only be multiplied with unitless quantities, convert them to (K) first
+//
+// In exact mode, a warning is emitted if the result can not be represented exactly, like for division by zero.
 func (s *State) OperatorDivide() (err error) {
 	var operand1, operand2 *quantity.Q
 	operand2, err = s.StackPop()
@@ -149,17 +193,38 @@ func (s *State) OperatorDivide() (err error) {
 		}
 	}()
 
+	scale, ok := quantity.MulScales(*operand1, *operand2)
+	if !ok {
+		err = ErrAbsoluteScale{OffendingUnit: absoluteUnits(*operand1, *operand2)}
+		return
+	}
+
 	operand2.UnitExponents.Inverse()
 	res := quantity.Q{
-		Number:            operand1.Number / operand2.Number,
+		Number: operand1.Value().Quo(operand2.Value()),
+		Uncertainty: quantity.QuotientUncertainty(
+			operand1.Value().Float64(), operand1.Uncertainty,
+			operand2.Value().Float64(), operand2.Uncertainty),
 		UnitExponents:     append(operand1.UnitExponents, operand2.UnitExponents...),
+		Scale:             scale,
 		DerivedUnitsToUse: quantity.CombineUDerivedLists(operand1.DerivedUnitsToUse, operand2.DerivedUnitsToUse),
 	}
 	res.UnitExponents.Simplify()
 	s.StackPush(res)
+	if quantity.IsExact(operand2.Value()) {
+		return s.checkExact("/", operand1.Value(), res.Number)
+	}
 	return
 }
 
+// absoluteUnits finds the units of the absolute one of two operands
+func absoluteUnits(operand1 quantity.Q, operand2 quantity.Q) quantity.UCombination {
+	if operand1.Scale == quantity.ScaleAbsolute {
+		return operand1.UnitExponents
+	}
+	return operand2.UnitExponents
+}
+
 // OperatorR reverses the order of the top-most 2 elements on the stack
 func (s *State) OperatorR() (err error) {
 	var operand1, operand2 *quantity.Q
@@ -0,0 +1,33 @@
+package interpreter
+
+import "github.com/eternal-flame-ad/unitdc/quantity"
+
+// OperatorConst pops the name of a physical constant from the string stack,
+// and pushes its value onto the stack, with its uncertainty if it is not exact.
+//
+// The constants are NA, R, F, k, h, c, e, gn, G, me, mp and eps0, see quantity.BuiltinConstants.
+//
+// Example: "R" const will result in a quantity of 8314.46 (g)(m)2(s)-2(K)-1(mol)-1. Displayed as 8.31446 (J)(mol)-1(K)-1.
+//
+// Example: "me" const will result in a quantity of 9.1093837015e-28 (g) with a standard uncertainty of 2.8e-37 (g).
+// Displayed in (kg).
+func (s *State) OperatorConst() (err error) {
+	var name string
+	name, err = s.StringPop()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			s.StringPush(name)
+		}
+	}()
+
+	var c quantity.Constant
+	c, err = quantity.LookupConstant(name)
+	if err != nil {
+		return
+	}
+	s.StackPush(c.Q(s.Numbers))
+	return
+}
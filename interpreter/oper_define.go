@@ -0,0 +1,81 @@
+package interpreter
+
+import (
+	"math/big"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+)
+
+// OperatorDefine pops a name from the string stack and a quantity from the stack,
+// and defines a new unit of that name equal to the quantity.
+//
+// Example: 2.5 (mg) "vial" define 3 (vial) (mg) will result in a quantity of 7.5 (mg).
+//
+// The new unit accepts SI prefixes like any other unit, and is saved with the session.
+// The quantity must be positive and can not be an absolute temperature.
+// Units are exact, so the uncertainty of the quantity is dropped: 1.50±0.02 (g) "scoop" define
+// defines a scoop as exactly 1.5 (g).
+func (s *State) OperatorDefine() (err error) {
+	var name string
+	name, err = s.StringPop()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			s.StringPush(name)
+		}
+	}()
+	var operand *quantity.Q
+	operand, err = s.StackPop()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			s.StackPush(*operand)
+		}
+	}()
+
+	if operand.Scale == quantity.ScaleAbsolute {
+		err = ErrAbsoluteScale{OffendingUnit: operand.UnitExponents}
+		return
+	}
+	multiplier := operand.Number.Float64()
+	if !(multiplier > 0) {
+		err = quantity.ErrInvalidMultiplier{Multiplier: multiplier}
+		return
+	}
+
+	derived := quantity.NewUDerived(name, multiplier, operand.UnitExponents)
+	if exact := quantity.ToRat(operand.Number); exact != nil {
+		// keep the precision of big float and rational numbers, like for 1 3 / "third" define
+		derived = derived.WithExactFactors(exact, new(big.Rat))
+	}
+	derived.Interval = operand.Scale == quantity.ScaleInterval
+	if err = s.Registry.AddDerivedUnit(derived); err != nil {
+		return
+	}
+	s.Defined.Derived = append(s.Defined.Derived, quantity.NewDerivedDefinition(derived))
+	return s.saveDefinitions()
+}
+
+// LoadSession adds units defined in an earlier session,
+// they will be saved again with the current session.
+func (s *State) LoadSession(defs quantity.Definitions) error {
+	if err := s.Registry.AddDefinitions(defs); err != nil {
+		return err
+	}
+	s.Defined.Requires = append(s.Defined.Requires, defs.Requires...)
+	s.Defined.Units = append(s.Defined.Units, defs.Units...)
+	s.Defined.Derived = append(s.Defined.Derived, defs.Derived...)
+	s.Defined.Aliases = append(s.Defined.Aliases, defs.Aliases...)
+	s.Defined.Substances = append(s.Defined.Substances, defs.Substances...)
+	if len(defs.Prefixes) > 0 && s.Defined.Prefixes == nil {
+		s.Defined.Prefixes = make(map[string][]quantity.Prefix)
+	}
+	for name, prefixes := range defs.Prefixes {
+		s.Defined.Prefixes[name] = prefixes
+	}
+	return nil
+}
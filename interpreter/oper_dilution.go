@@ -0,0 +1,156 @@
+package interpreter
+
+import (
+	"math"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+)
+
+// The dilution operators solve C1 V1 = C2 V2 for the missing term, where C1 is the stock concentration,
+// V1 the volume of stock, C2 the final concentration and V2 the final volume.
+//
+// Concentrations may be given in any units of the same kind, like (mM) and (uM),
+// and so may volumes. Results are displayed in the units of the given volume or concentration.
+
+// dilutionRatio computes the ratio C2 / C1 of two concentrations of the same kind and its uncertainty
+func dilutionRatio(operation string, c1 quantity.Q, c2 quantity.Q) (ratio quantity.Number, uncertainty float64, err error) {
+	if !c1.UnitExponents.Equal(&c2.UnitExponents) {
+		return nil, 0, ErrIncompatibleUnit{TargetUnit: c1.UnitExponents, OffendingUnit: c2.UnitExponents}
+	}
+	if c1.Scale == quantity.ScaleAbsolute || c2.Scale == quantity.ScaleAbsolute {
+		return nil, 0, ErrAbsoluteScale{OffendingUnit: c1.UnitExponents}
+	}
+	ratio = c2.Number.Quo(c1.Number)
+	if r := ratio.Float64(); !(r >= 0 && r <= 1) {
+		return nil, 0, ErrImpossibleDilution{Operation: operation}
+	}
+	uncertainty = quantity.QuotientUncertainty(c2.Number.Float64(), c2.Uncertainty, c1.Number.Float64(), c1.Uncertainty)
+	return
+}
+
+// diluent computes the volume of diluent V2 - V1 = V2 (1 - r) from the final volume v2 and the dilution ratio r
+func diluent(v2 quantity.Q, ratio quantity.Number, ratioUncertainty float64) quantity.Q {
+	res := v2
+	res.Number = v2.Number.Sub(v2.Number.Mul(ratio))
+	res.Uncertainty = 0
+	if v2.Uncertainty != 0 || ratioUncertainty != 0 {
+		res.Uncertainty = math.Hypot((1-ratio.Float64())*v2.Uncertainty, v2.Number.Float64()*ratioUncertainty)
+	}
+	return res
+}
+
+// OperatorDil pops the stock concentration C1, the final concentration C2 and the final volume V2 from the stack,
+// and pushes the volume of stock V1 and the volume of diluent V2 - V1 onto the stack.
+//
+// Example: 100 (mM) 50 (uM) 10 (ml) dil will result in 5e-06 (l) of stock and 0.009995 (l) of diluent.
+// Displayed as 0.005 (ml) and 9.995 (ml).
+func (s *State) OperatorDil() (err error) {
+	var operands []quantity.Q
+	operands, err = s.StackPopN(3)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			for _, q := range operands {
+				s.StackPush(q)
+			}
+		}
+	}()
+	c1, c2, v2 := operands[0], operands[1], operands[2]
+
+	ratio, ratioUncertainty, err := dilutionRatio("dil", c1, c2)
+	if err != nil {
+		return
+	}
+	v1 := v2
+	v1.Number = v2.Number.Mul(ratio)
+	v1.Uncertainty = quantity.ProductUncertainty(v2.Number.Float64(), v2.Uncertainty, ratio.Float64(), ratioUncertainty)
+	s.StackPush(v1)
+	s.StackPush(diluent(v2, ratio, ratioUncertainty))
+	return
+}
+
+// OperatorDilV pops the stock concentration C1, the volume of stock V1 and the final concentration C2 from the stack,
+// and pushes the final volume V2 and the volume of diluent V2 - V1 onto the stack.
+//
+// Example: 100 (mM) 5 (ul) 50 (uM) dilv will result in 0.01 (l) final volume and 0.009995 (l) of diluent.
+// Displayed as 10000 (ul) and 9995 (ul).
+func (s *State) OperatorDilV() (err error) {
+	var operands []quantity.Q
+	operands, err = s.StackPopN(3)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			for _, q := range operands {
+				s.StackPush(q)
+			}
+		}
+	}()
+	c1, v1, c2 := operands[0], operands[1], operands[2]
+
+	ratio, ratioUncertainty, err := dilutionRatio("dilv", c1, c2)
+	if err != nil {
+		return
+	}
+	if ratio.Float64() == 0 {
+		err = ErrImpossibleDilution{Operation: "dilv"}
+		return
+	}
+	v2 := v1
+	v2.Number = v1.Number.Quo(ratio)
+	v2.Uncertainty = quantity.QuotientUncertainty(v1.Number.Float64(), v1.Uncertainty, ratio.Float64(), ratioUncertainty)
+	s.StackPush(v2)
+	dil := v2
+	dil.Number = v2.Number.Sub(v1.Number)
+	// V2 - V1 = V1 (1 / r - 1)
+	dil.Uncertainty = 0
+	if v1.Uncertainty != 0 || ratioUncertainty != 0 {
+		r := ratio.Float64()
+		dil.Uncertainty = math.Hypot((1/r-1)*v1.Uncertainty, v1.Number.Float64()*ratioUncertainty/(r*r))
+	}
+	s.StackPush(dil)
+	return
+}
+
+// OperatorDilC pops the stock concentration C1, the volume of stock V1 and the final volume V2 from the stack,
+// and pushes the final concentration C2 onto the stack.
+//
+// Example: 100 (mM) 5 (ul) 10 (ml) dilc will result in a quantity of 5e-05 (mol)(l)-1. Displayed as 0.05 (mM).
+func (s *State) OperatorDilC() (err error) {
+	var operands []quantity.Q
+	operands, err = s.StackPopN(3)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			for _, q := range operands {
+				s.StackPush(q)
+			}
+		}
+	}()
+	c1, v1, v2 := operands[0], operands[1], operands[2]
+
+	if !v1.UnitExponents.Equal(&v2.UnitExponents) {
+		err = ErrIncompatibleUnit{TargetUnit: v2.UnitExponents, OffendingUnit: v1.UnitExponents}
+		return
+	}
+	if c1.Scale == quantity.ScaleAbsolute {
+		err = ErrAbsoluteScale{OffendingUnit: c1.UnitExponents}
+		return
+	}
+	ratio := v1.Number.Quo(v2.Number)
+	if r := ratio.Float64(); !(r >= 0 && r <= 1) {
+		err = ErrImpossibleDilution{Operation: "dilc"}
+		return
+	}
+	ratioUncertainty := quantity.QuotientUncertainty(v1.Number.Float64(), v1.Uncertainty, v2.Number.Float64(), v2.Uncertainty)
+	c2 := c1
+	c2.Number = c1.Number.Mul(ratio)
+	c2.Uncertainty = quantity.ProductUncertainty(c1.Number.Float64(), c1.Uncertainty, ratio.Float64(), ratioUncertainty)
+	s.StackPush(c2)
+	return
+}
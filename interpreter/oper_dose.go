@@ -0,0 +1,182 @@
+package interpreter
+
+import (
+	"math"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+)
+
+// Body sizes that doses are given per unit of
+var (
+	bodyWeight      = quantity.UCombination{{Unit: quantity.UnitGram, Exponent: quantity.IntExponent(1)}}
+	bodyHeight      = quantity.UCombination{{Unit: quantity.UnitMeter, Exponent: quantity.IntExponent(1)}}
+	bodySurfaceArea = quantity.UCombination{{Unit: quantity.UnitMeter, Exponent: quantity.IntExponent(2)}}
+)
+
+// applyDose pops a dose per unit of body size and a body size from the stack in any order,
+// and pushes the dose for that body onto the stack.
+//
+// perSize reports whether a dose is given per unit of body size.
+func (s *State) applyDose(size quantity.UCombination, perSize func(dose quantity.Q) bool) (err error) {
+	var operands []quantity.Q
+	operands, err = s.StackPopN(2)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			for _, q := range operands {
+				s.StackPush(q)
+			}
+		}
+	}()
+	dose, body := operands[0], operands[1]
+	if dose.UnitExponents.Equal(&size) && !body.UnitExponents.Equal(&size) {
+		dose, body = body, dose
+	}
+
+	if !body.UnitExponents.Equal(&size) {
+		err = ErrIncompatibleUnit{TargetUnit: size, OffendingUnit: body.UnitExponents}
+		return
+	}
+	if dose.Bare || !perSize(dose) {
+		err = ErrIncompatibleUnit{OffendingUnit: dose.UnitExponents}
+		return
+	}
+	s.StackPush(dose)
+	s.StackPush(body)
+	return s.OperatorMultiply()
+}
+
+// OperatorDose pops a dose per body weight and a body weight from the stack,
+// and pushes the dose for that weight onto the stack.
+//
+// The dose must be given per unit of mass, like (mg)(kg)-1, (ml)(kg)-1(h)-1 or (iu:insulin)(kg)-1,
+// doses like 5 (mg), 5 (ml), 5 (iu:insulin) or numbers without a unit are refused.
+//
+// Example: 5 (mg) 1 (kg) / 70 (kg) dose will result in a quantity of 0.35 (g). Displayed as 350 (mg).
+func (s *State) OperatorDose() (err error) {
+	return s.applyDose(bodyWeight, func(dose quantity.Q) bool {
+		switch dose.UnitExponents.ExponentOf(quantity.UnitGram).Sign() {
+		case -1:
+			return true
+		case 0:
+			// the base unit of mass is the gram, so a mass per body weight like (mg)(kg)-1 has no mass,
+			// it is only recognized by its preferred units
+			return hasMassUnit(dose.DerivedUnitsToUse)
+		}
+		return false
+	})
+}
+
+// hasMassUnit tells whether any of the units contains a mass, like mg or mg/ml
+func hasMassUnit(units quantity.UDerivedList) bool {
+	for _, d := range units {
+		if !d.UnitExponents.ExponentOf(quantity.UnitGram).IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// OperatorBSADose pops a dose per body surface area and a body surface area from the stack,
+// and pushes the dose for that surface area onto the stack.
+//
+// The dose must be given per unit of area, like (mg)(m)-2.
+//
+// Example: 75 (mg) 1 (m) 1 (m) * / 180 (cm) 70 (kg) bsa bsadose will result in a quantity of 0.1403 (g). Displayed as 140.3 (mg).
+func (s *State) OperatorBSADose() (err error) {
+	return s.applyDose(bodySurfaceArea, func(dose quantity.Q) bool {
+		return dose.UnitExponents.ExponentOf(quantity.UnitMeter).Add(quantity.IntExponent(2)).Sign() <= 0
+	})
+}
+
+// bsaFormula estimates the body surface area in (m)2 as coefficient * W^weightExponent * H^heightExponent,
+// from the body weight W in kilograms and the height H in centimeters
+type bsaFormula struct {
+	operation      string
+	coefficient    float64
+	weightExponent float64
+	heightExponent float64
+}
+
+var (
+	// bsaMosteller is sqrt(W H / 3600)
+	bsaMosteller = bsaFormula{operation: "bsa", coefficient: 1. / 60, weightExponent: 0.5, heightExponent: 0.5}
+	bsaDuBois    = bsaFormula{operation: "bsadubois", coefficient: 0.007184, weightExponent: 0.425, heightExponent: 0.725}
+	bsaHaycock   = bsaFormula{operation: "bsahaycock", coefficient: 0.024265, weightExponent: 0.5378, heightExponent: 0.3964}
+)
+
+// applyBSAFormula pops a height and a body weight from the stack in any order,
+// and pushes the body surface area estimated by fn onto the stack
+func (s *State) applyBSAFormula(fn bsaFormula) (err error) {
+	var operands []quantity.Q
+	operands, err = s.StackPopN(2)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			for _, q := range operands {
+				s.StackPush(q)
+			}
+		}
+	}()
+	height, weight := operands[0], operands[1]
+	if height.UnitExponents.Equal(&bodyWeight) {
+		height, weight = weight, height
+	}
+
+	if !height.UnitExponents.Equal(&bodyHeight) {
+		err = ErrIncompatibleUnit{TargetUnit: bodyHeight, OffendingUnit: height.UnitExponents}
+		return
+	}
+	if !weight.UnitExponents.Equal(&bodyWeight) {
+		err = ErrIncompatibleUnit{TargetUnit: bodyWeight, OffendingUnit: weight.UnitExponents}
+		return
+	}
+	h, w := height.Number.Float64(), weight.Number.Float64()
+	if !(h > 0) {
+		err = ErrDomain{Operation: fn.operation, Number: h}
+		return
+	}
+	if !(w > 0) {
+		err = ErrDomain{Operation: fn.operation, Number: w}
+		return
+	}
+
+	bsa := fn.coefficient * math.Pow(w/1000, fn.weightExponent) * math.Pow(h*100, fn.heightExponent)
+	res := quantity.Q{
+		Number:        s.Numbers.FromFloat64(bsa),
+		UnitExponents: bodySurfaceArea.Clone(),
+	}
+	if height.Uncertainty != 0 || weight.Uncertainty != 0 {
+		res.Uncertainty = bsa * math.Hypot(fn.weightExponent*weight.Uncertainty/w, fn.heightExponent*height.Uncertainty/h)
+	}
+	s.StackPush(res)
+	return s.checkExact(fn.operation, weight.Number, res.Number)
+}
+
+// OperatorBSA pops a height and a body weight from the stack,
+// and pushes the body surface area by the Mosteller formula onto the stack.
+//
+// Example: 180 (cm) 70 (kg) bsa will result in a quantity of 1.871 (m)2.
+func (s *State) OperatorBSA() (err error) {
+	return s.applyBSAFormula(bsaMosteller)
+}
+
+// OperatorBSADuBois pops a height and a body weight from the stack,
+// and pushes the body surface area by the DuBois formula onto the stack.
+//
+// Example: 180 (cm) 70 (kg) bsadubois will result in a quantity of 1.886 (m)2.
+func (s *State) OperatorBSADuBois() (err error) {
+	return s.applyBSAFormula(bsaDuBois)
+}
+
+// OperatorBSAHaycock pops a height and a body weight from the stack,
+// and pushes the body surface area by the Haycock formula onto the stack.
+//
+// Example: 180 (cm) 70 (kg) bsahaycock will result in a quantity of 1.868 (m)2.
+func (s *State) OperatorBSAHaycock() (err error) {
+	return s.applyBSAFormula(bsaHaycock)
+}
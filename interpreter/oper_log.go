@@ -0,0 +1,99 @@
+package interpreter
+
+import (
+	"math"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+)
+
+// logFunction is a function of dimensionless quantities, with its domain and derivative
+// for propagating uncertainties
+type logFunction struct {
+	operation  string
+	f          func(float64) float64
+	derivative func(float64) float64
+	domain     func(float64) bool
+}
+
+var (
+	logFunctionLn = logFunction{
+		operation:  "ln",
+		f:          math.Log,
+		derivative: func(x float64) float64 { return 1 / x },
+		domain:     func(x float64) bool { return x > 0 },
+	}
+	logFunctionLog10 = logFunction{
+		operation:  "log10",
+		f:          math.Log10,
+		derivative: func(x float64) float64 { return 1 / (x * math.Ln10) },
+		domain:     func(x float64) bool { return x > 0 },
+	}
+	logFunctionExp = logFunction{
+		operation:  "exp",
+		f:          math.Exp,
+		derivative: math.Exp,
+		domain:     func(x float64) bool { return !math.IsInf(math.Exp(x), 0) },
+	}
+	logFunctionPow10 = logFunction{
+		operation:  "pow10",
+		f:          func(x float64) float64 { return math.Pow(10, x) },
+		derivative: func(x float64) float64 { return math.Pow(10, x) * math.Ln10 },
+		domain:     func(x float64) bool { return !math.IsInf(math.Pow(10, x), 0) },
+	}
+)
+
+// applyLogFunction pops a dimensionless quantity from the stack, and pushes the result of fn on it
+func (s *State) applyLogFunction(fn logFunction) (err error) {
+	var operand *quantity.Q
+	operand, err = s.StackPop()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			s.StackPush(*operand)
+		}
+	}()
+
+	if !operand.UnitExponents.IsNoUnit() {
+		err = ErrIncompatibleUnit{OffendingUnit: operand.UnitExponents}
+		return
+	}
+	x := operand.Number.Float64()
+	if !fn.domain(x) {
+		err = ErrDomain{Operation: fn.operation, Number: x}
+		return
+	}
+
+	res := quantity.Q{
+		Number: s.Numbers.FromFloat64(fn.f(x)),
+	}
+	if operand.Uncertainty != 0 {
+		res.Uncertainty = math.Abs(fn.derivative(x)) * operand.Uncertainty
+	}
+	s.StackPush(res)
+	return s.checkExact(fn.operation, operand.Number, res.Number)
+}
+
+// OperatorLn pops a dimensionless quantity from the stack, and pushes its natural logarithm onto the stack
+func (s *State) OperatorLn() (err error) {
+	return s.applyLogFunction(logFunctionLn)
+}
+
+// OperatorLog10 pops a dimensionless quantity from the stack, and pushes its decimal logarithm onto the stack
+//
+// Example: the ratio of base to acid of a buffer at a pH follows from the Henderson–Hasselbalch equation,
+// 4.76 (pKa) 5 (pH) / will result in the ratio Ka / [H+] of 1.738, and log10 of it is pH - pKa = 0.24.
+func (s *State) OperatorLog10() (err error) {
+	return s.applyLogFunction(logFunctionLog10)
+}
+
+// OperatorExp pops a dimensionless quantity from the stack, and pushes e to the power of it onto the stack
+func (s *State) OperatorExp() (err error) {
+	return s.applyLogFunction(logFunctionExp)
+}
+
+// OperatorPow10 pops a dimensionless quantity from the stack, and pushes 10 to the power of it onto the stack
+func (s *State) OperatorPow10() (err error) {
+	return s.applyLogFunction(logFunctionPow10)
+}
@@ -0,0 +1,83 @@
+package interpreter
+
+import (
+	"github.com/eternal-flame-ad/unitdc/chem"
+	"github.com/eternal-flame-ad/unitdc/quantity"
+)
+
+// OperatorMW pops a chemical formula or the name of a common compound from the string stack,
+// and pushes its molar mass onto the stack, displayed in (Da).
+//
+// Example: "C6H12O6" mw will result in a quantity of 180.156 (g)(mol)-1. Displayed as 180.156 (Da).
+//
+// Example: "CuSO4·5H2O" mw will result in a quantity of 249.677 (Da), including the water of crystallization.
+//
+// Example: "tris" mw will result in a quantity of 121.136 (Da).
+func (s *State) OperatorMW() (err error) {
+	var formula string
+	formula, err = s.StringPop()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			s.StringPush(formula)
+		}
+	}()
+
+	var f chem.Formula
+	f, err = chem.ParseCompound(formula)
+	if err != nil {
+		return
+	}
+	s.StackPush(quantity.Q{
+		Number:            s.Numbers.FromRat(f.MolarMassRat()),
+		UnitExponents:     quantity.UnitDerivedAmu.UnitExponents.Clone(),
+		DerivedUnitsToUse: quantity.UDerivedList{quantity.UnitDerivedAmu},
+	})
+	return
+}
+
+// OperatorMWConvert pops a molar mass and a quantity from the stack, and converts the quantity
+// between mass and amount of substance with the molar mass.
+//
+// Quantities in grams are converted into moles, and quantities in moles into grams,
+// see quantity.ConvertMolar.
+//
+// Example: 5.844 (mg) 1 (ml) / "NaCl" mw mwconv will result in a quantity of 0.1 (mol)(l)-1. Displayed as 0.1 (M).
+//
+// Example: 20 (mM) 1 (ml) * 58.44 (Da) mwconv (mg) will result in a quantity of 0.0011688 (g). Displayed as 1.1688 (mg).
+func (s *State) OperatorMWConvert() (err error) {
+	var mw *quantity.Q
+	mw, err = s.StackPop()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			s.StackPush(*mw)
+		}
+	}()
+	var operand *quantity.Q
+	operand, err = s.StackPop()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			s.StackPush(*operand)
+		}
+	}()
+
+	if !mw.UnitExponents.Equal(&quantity.UnitDerivedAmu.UnitExponents) {
+		err = ErrIncompatibleUnit{TargetUnit: quantity.UnitDerivedAmu.UnitExponents, OffendingUnit: mw.UnitExponents}
+		return
+	}
+	res, ok := quantity.ConvertMolar(*operand, *mw)
+	if !ok {
+		err = ErrIncompatibleUnit{OffendingUnit: operand.UnitExponents}
+		return
+	}
+	s.StackPush(res)
+	return
+}
@@ -1,7 +1,7 @@
 package interpreter
 
 import (
-	"math"
+	"strings"
 
 	"github.com/eternal-flame-ad/unitdc/quantity"
 	"github.com/eternal-flame-ad/unitdc/syntax"
@@ -45,7 +45,9 @@ func (s *State) OperatorN() (err error) {
 
 // OperatorV pops the quantity of top of stack, and pushes its square root onto the stack
 //
-// All involved base units must be of even exponents.
+// Exponents of the involved base units are halved, which may result in fractional exponents.
+//
+// In exact mode, a warning is emitted if the result can not be represented exactly.
 func (s *State) OperatorV() (err error) {
 	var operand *quantity.Q
 	operand, err = s.StackPop()
@@ -59,23 +61,15 @@ func (s *State) OperatorV() (err error) {
 	}()
 
 	res := quantity.Q{
-		Number:            math.Sqrt(operand.Number),
+		Number:            operand.Number.Sqrt(),
+		Uncertainty:       quantity.SqrtUncertainty(operand.Number.Float64(), operand.Uncertainty),
 		DerivedUnitsToUse: operand.DerivedUnitsToUse,
 		UnitExponents:     operand.UnitExponents,
 	}
+	res.UnitExponents.Pow(quantity.NewExponent(1, 2))
 	res.UnitExponents.Simplify()
-	for i := range res.UnitExponents {
-		if res.UnitExponents[i].Exponent%2 != 0 {
-			err = ErrIncompatibleUnit{
-				OffendingUnit: res.UnitExponents,
-				TargetUnit:    nil,
-			}
-			return
-		}
-		res.UnitExponents[i].Exponent /= 2
-	}
 	s.StackPush(res)
-	return
+	return s.checkExact("v", operand.Number, res.Number)
 }
 
 // OperatorF prints the content of the whole stack
@@ -98,10 +92,7 @@ func (s *State) OperatorD() (err error) {
 
 	s.StackPush(*operand)
 
-	operandCopy := *operand
-	operandCopy.UnitExponents = operandCopy.UnitExponents.Clone()
-	operandCopy.DerivedUnitsToUse = operandCopy.DerivedUnitsToUse.Clone()
-	s.StackPush(operandCopy)
+	s.StackPush(operand.Clone())
 	return
 }
 
@@ -144,6 +135,64 @@ func (s *State) OperatorD() (err error) {
 //
 // Example: 1 (mol) 1 (ml) / (uM) will result in a quantity of 1 (mol)(l)-1. Displayed as 1000000 (uM).
 //
+// Units with an offset (degC, degF) mark a bare number as an absolute temperature, their interval
+// counterparts (ΔdegC, ΔdegF) mark it as a temperature difference:
+//
+// Example: 20 (degC) 10 (degC) - will result in a quantity of 10 (K). Displayed as 10 (ΔdegC).
+//
+// Example: 20 (degC) 5 (ΔdegF) + will result in a quantity of 295.928 (K). Displayed as 22.778 (degC).
+//
+// Example: 20 (degC) 10 (degC) + is an error, as is converting an absolute temperature to (ΔdegC).
+//
+// The IU of a substance, like (iu:vitD3), is converted to and from mass with the mass of one IU of the substance,
+// converting into the IU of another substance is an error:
+//
+// Example: 400 (iu:vitD3) (ug) will result in a quantity of 0.00001 (g). Displayed as 10 (ug).
+//
+// Example: 10 (ug) (iu:vitD3) will result in a quantity of 400 (iu:vitD3).
+//
+// Example: 400 (iu:vitD3) (iu:vitD2) is an error.
+//
+// Dimensionless units like (%), (ppm) or (%v/v) scale numbers entered without a unit,
+// and change how other dimensionless quantities are displayed:
+//
+// Example: 5 (%) will result in a quantity of 0.05. Displayed as 5 (%).
+//
+// Example: 1 (mg) 20 (g) / (ppm) will result in a quantity of 0.00005. Displayed as 50 (ppm).
+//
+// Example: 5 (%) (1) will result in a quantity of 0.05. Displayed as 0.05.
+//
+// Logarithmic units like (pH), (pKa) or (dB) convert numbers entered without a unit into their linear value,
+// and display quantities of the same kind in the logarithmic unit:
+//
+// Example: 7.4 (pH) will result in a quantity of 3.98e-08 (mol)(l)-1. Displayed as 7.4 (pH).
+//
+// Example: 1 (mM) (pH) will result in a quantity of 0.001 (mol)(l)-1. Displayed as 3 (pH).
+//
+// Example: 3 (dB) will result in a quantity of 1.995. Displayed as 3 (dB).
+//
+// Units of time other than the second, (min), (h), (day) and (week), are kept for display
+// until they are converted away, so rates can be displayed in any of them:
+//
+// Example: 1 (ml) 1 (h) / (ul) (min) will result in a quantity of 2.78e-07 (l)(s)-1. Displayed as 16.667 (ul)(min)-1.
+//
+// Example: 5 (mg) 1 (ml) / 10 (ml) 1 (h) / * will result in a quantity of 0.0139 (g)(s)-1. Displayed as 50 (mg)(h)-1.
+//
+// Units can be combined into expressions with *, / and ^, see syntax.ParseUnitExpression.
+// Numbers entered without a unit are tagged with the expression, other quantities must be
+// of the same kind and are displayed in the units of the expression:
+//
+// Example: 5 (mg/ml) will result in a quantity of 5 (g)(l)-1. Displayed as 5 (mg)(ml)-1.
+//
+// Example: 5 (%w/v) (mg/ml) will result in a quantity of 50 (g)(l)-1. Displayed as 50 (mg)(ml)-1.
+//
+// Example: 1 (ml) (mg/ml) is an error.
+//
+// Registered identifiers are looked up as a whole first, so (%w/v) is not read as (%w)/(v).
+//
+// Any SI prefix (from yocto to yotta) can be applied on any unit that is not already prefixed,
+// so (kg), (fmol), (Ml) or (GHz) do not need to be registered in advance.
+//
 // Real-life examples on the use of unit operators:
 //
 //
@@ -179,65 +228,148 @@ func (s *State) OperatorUnitConvert(unitTok syntax.TokenUnit) (err error) {
 		return
 	}
 	defer func() {
+		// the quantity is left as is when the conversion failed
+		if err == nil {
+			operand.Bare = false
+		}
 		s.StackPush(*operand)
 	}()
 
+	bare := operand.Bare
+
 	if unit == "1" {
 		operand.UnitExponents = quantity.UCombination{}
+		operand.Scale = quantity.ScaleRatio
+		operand.DerivedUnitsToUse = withoutDimensionless(operand.DerivedUnitsToUse)
 		return
 	}
 
-	err = ErrUnknownUnit{unit}
-	if operand.UnitExponents.IsNoUnit() {
-		for _, u := range s.DerivedUnits {
-			if u.Identifier == unit {
-				err = nil
-				operand.UnitExponents = make(quantity.UCombination, len(u.UnitExponents))
-				copy(operand.UnitExponents, u.UnitExponents)
-				operand.Number = operand.Number*u.Multiplier + u.Offset
-			}
+	base, derived := s.Registry.Lookup(unit)
+	if base == nil && derived == nil {
+		// identifiers like %w/v are looked up as a whole first
+		if strings.ContainsAny(unit, syntax.UnitExpressionOperators) {
+			err = s.convertUnitExpression(operand, bare, unitTok)
+			return
 		}
-		for _, u := range s.Units {
-			if u.Identifier == unit {
-				err = nil
-				operand.UnitExponents = quantity.UCombination{quantity.UExp{Unit: u, Exponent: 1}}
-			}
+		err = ErrUnknownUnit{unit}
+		return
+	}
+
+	if derived != nil && derived.IsLogarithmic() {
+		if bare {
+			v := operand.Number
+			operand.Uncertainty = derived.FromLogUncertainty(v.Float64(), operand.Uncertainty)
+			operand.Number = s.Numbers.FromFloat64(derived.FromLog(v.Float64()))
+			operand.UnitExponents = derived.UnitExponents.Clone()
+			defer func() {
+				err = s.checkExact("("+unit+")", v, operand.Number)
+			}()
+		} else if !operand.UnitExponents.Equal(&derived.UnitExponents) {
+			err = ErrIncompatibleUnit{TargetUnit: derived.UnitExponents, OffendingUnit: operand.UnitExponents}
+			return
 		}
+		operand.DerivedUnitsToUse = quantity.UDerivedList{*derived}
+		operand.ExplicitUnits = true
+		return
 	}
-	for _, u := range s.DerivedUnits {
-		if u.Identifier == unit {
-			err = nil
-			var newDerivedUnitsToUse quantity.UDerivedList
-			for _, ud := range operand.DerivedUnitsToUse {
-				if !ud.UnitExponents.HasOverlap(u.UnitExponents) {
-					newDerivedUnitsToUse = append(newDerivedUnitsToUse, ud)
-				}
+
+	if derived != nil && derived.UnitExponents.IsNoUnit() {
+		if !operand.UnitExponents.IsNoUnit() {
+			err = ErrIncompatibleUnit{OffendingUnit: operand.UnitExponents}
+			return
+		}
+		if bare {
+			operand.Number = derived.ToBase(operand.Number)
+			operand.Uncertainty *= derived.Multiplier
+		}
+		operand.DerivedUnitsToUse = append(withoutDimensionless(operand.DerivedUnitsToUse), *derived)
+		operand.ExplicitUnits = true
+		return
+	}
+
+	if !operand.UnitExponents.IsNoUnit() {
+		target := quantity.UCombination{}
+		if derived != nil {
+			target = derived.UnitExponents
+		} else {
+			target = quantity.UCombination{{Unit: *base, Exponent: quantity.IntExponent(1)}}
+		}
+		var converted quantity.Q
+		if converted, err = s.Registry.ConvertSubstance(*operand, target); err != nil {
+			return
+		}
+		*operand = converted
+	}
+
+	if operand.UnitExponents.IsNoUnit() {
+		if derived != nil {
+			operand.UnitExponents = derived.UnitExponents.Clone()
+			operand.Number = derived.ToBase(operand.Number)
+			operand.Uncertainty *= derived.Multiplier
+			if derived.Offset != 0 {
+				operand.Scale = quantity.ScaleAbsolute
+			} else if derived.Interval {
+				operand.Scale = quantity.ScaleInterval
 			}
-			newDerivedUnitsToUse = append(newDerivedUnitsToUse, u)
-			operand.DerivedUnitsToUse = newDerivedUnitsToUse
+		} else {
+			operand.UnitExponents = quantity.UCombination{quantity.UExp{Unit: *base, Exponent: quantity.IntExponent(1)}}
 		}
 	}
-	for _, u := range s.Units {
-		if u.Identifier == unit {
-			err = nil
-
-			// clear all preferred derived units the contain this unit
-			var newDerivedUnitsToUse quantity.UDerivedList
-			for _, ud := range operand.DerivedUnitsToUse {
-				conflict := false
-				for _, udu := range ud.UnitExponents {
-					if udu.Unit.ID == u.ID {
-						conflict = true
-						break
-					}
-				}
-				if !conflict {
-					newDerivedUnitsToUse = append(newDerivedUnitsToUse, ud)
+	if derived != nil && derived.Interval && operand.Scale == quantity.ScaleAbsolute {
+		err = ErrAbsoluteScale{OffendingUnit: operand.UnitExponents}
+		return
+	}
+	// a difference like 5 Δ°C is no point on the scale of degC
+	if derived != nil && derived.Offset != 0 && operand.Scale == quantity.ScaleInterval &&
+		operand.UnitExponents.Equal(&derived.UnitExponents) {
+		err = ErrAbsoluteScale{OffendingUnit: operand.UnitExponents}
+		return
+	}
+	// temperatures in kelvin are on the ratio scale, and absolute again in offset units like degC
+	switch offset := derived != nil && derived.Offset != 0; {
+	case operand.Scale == quantity.ScaleAbsolute && !offset:
+		operand.Scale = quantity.ScaleRatio
+	case operand.Scale == quantity.ScaleRatio && offset && operand.UnitExponents.Equal(&derived.UnitExponents):
+		operand.Scale = quantity.ScaleAbsolute
+	}
+	if derived != nil {
+		var newDerivedUnitsToUse quantity.UDerivedList
+		for _, ud := range operand.DerivedUnitsToUse {
+			if !ud.UnitExponents.HasOverlap(derived.UnitExponents) {
+				newDerivedUnitsToUse = append(newDerivedUnitsToUse, ud)
+			}
+		}
+		newDerivedUnitsToUse = append(newDerivedUnitsToUse, *derived)
+		operand.DerivedUnitsToUse = newDerivedUnitsToUse
+	} else {
+		// clear all preferred derived units the contain this unit
+		var newDerivedUnitsToUse quantity.UDerivedList
+		for _, ud := range operand.DerivedUnitsToUse {
+			conflict := false
+			for _, udu := range ud.UnitExponents {
+				if udu.Unit.ID == base.ID {
+					conflict = true
+					break
 				}
 			}
-			operand.DerivedUnitsToUse = newDerivedUnitsToUse
+			if !conflict {
+				newDerivedUnitsToUse = append(newDerivedUnitsToUse, ud)
+			}
 		}
+		operand.DerivedUnitsToUse = newDerivedUnitsToUse
 	}
+	// units given to a number are prefixed automatically like results, converted quantities are kept as is
+	operand.ExplicitUnits = !bare
+
+	return
+}
 
+// withoutDimensionless removes dimensionless units like (%) from a list of preferred units
+func withoutDimensionless(units quantity.UDerivedList) (res quantity.UDerivedList) {
+	for _, d := range units {
+		if !d.UnitExponents.IsNoUnit() {
+			res = append(res, d)
+		}
+	}
 	return
 }
@@ -5,18 +5,13 @@ import "github.com/eternal-flame-ad/unitdc/quantity"
 func (s *State) StackCopy() (q []quantity.Q) {
 	q = make([]quantity.Q, s.StackDepth())
 	for i := range q {
-		q[i] = quantity.Q{
-			Number:            s.Stack[i].Number,
-			UnitExponents:     s.Stack[i].UnitExponents.Clone(),
-			DerivedUnitsToUse: s.Stack[i].DerivedUnitsToUse.Clone(),
-		}
+		q[i] = s.Stack[i].Clone()
 	}
 	return
 }
 
 func (s *State) StackPush(q quantity.Q) {
-	q.UnitExponents = q.UnitExponents.Clone()
-	q.DerivedUnitsToUse = q.DerivedUnitsToUse.Clone()
+	q = q.Clone()
 	if s.StackPointer == MaxStackDepth-1 {
 		// stack is full
 		copy(s.Stack[:], s.Stack[1:])
@@ -31,19 +26,46 @@ func (s *State) StackPop() (q *quantity.Q, err error) {
 	if s.StackPointer == -1 {
 		return nil, ErrEmptyStack{}
 	}
-	q = &quantity.Q{
-		Number:            s.Stack[s.StackPointer].Number,
-		UnitExponents:     s.Stack[s.StackPointer].UnitExponents.Clone(),
-		DerivedUnitsToUse: s.Stack[s.StackPointer].DerivedUnitsToUse.Clone(),
-	}
+	res := s.Stack[s.StackPointer].Clone()
+	q = &res
 	s.StackPointer--
 	return
 }
 
 func (s *State) StackClear() {
 	s.StackPointer = -1
+	s.Strings = nil
 }
 
 func (s *State) StackDepth() int {
 	return s.StackPointer + 1
 }
+
+func (s *State) StringPush(str string) {
+	s.Strings = append(s.Strings, str)
+}
+
+func (s *State) StringPop() (str string, err error) {
+	if len(s.Strings) == 0 {
+		return "", ErrEmptyStringStack{}
+	}
+	str = s.Strings[len(s.Strings)-1]
+	s.Strings = s.Strings[:len(s.Strings)-1]
+	return
+}
+
+// StackPopN pops n quantities from the stack, the top of stack last.
+//
+// The stack is left untouched if it holds less than n quantities.
+func (s *State) StackPopN(n int) (q []quantity.Q, err error) {
+	if s.StackDepth() < n {
+		return nil, ErrEmptyStack{}
+	}
+	q = make([]quantity.Q, n)
+	for i := n - 1; i >= 0; i-- {
+		var operand *quantity.Q
+		operand, _ = s.StackPop()
+		q[i] = *operand
+	}
+	return
+}
@@ -0,0 +1,143 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTemperature(t *testing.T) {
+	Convey("Test Temperatures", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+
+		Convey("absolute temperatures should convert with offset", func() {
+			mockInput.tokenize("20 (degC)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 293.15)
+			So(top().Scale, ShouldEqual, quantity.ScaleAbsolute)
+
+			num, unit := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 20)
+			So(unit, ShouldResemble, quantity.UnitDisplayList{{Identifier: "degC", Exponent: quantity.IntExponent(1)}})
+
+			mockInput.tokenize("(degF)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			num, _ = top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 68)
+		})
+		Convey("adding two absolute temperatures should error", func() {
+			mockInput.tokenize("20 (degC) 30 (degC) +")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrAbsoluteScale{})
+			So(mockInterpreter.StackDepth(), ShouldEqual, 2)
+		})
+		Convey("subtracting two absolute temperatures should give a difference", func() {
+			mockInput.tokenize("30 (degC) 20 (degC) -")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Scale, ShouldEqual, quantity.ScaleInterval)
+			num, unit := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 10)
+			So(unit, ShouldResemble, quantity.UnitDisplayList{{Identifier: "ΔdegC", Exponent: quantity.IntExponent(1)}})
+
+			Convey("which can be added to an absolute temperature", func() {
+				mockInput.tokenize("25 (degC) r +")
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors)
+				So(top().Scale, ShouldEqual, quantity.ScaleAbsolute)
+				num, _ := top().Format()
+				So(num.Float64(), ShouldAlmostEqual, 35)
+			})
+			Convey("but not be subtracted by one", func() {
+				mockInput.tokenize("25 (degC) -")
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors, ErrAbsoluteScale{})
+			})
+		})
+		Convey("subtracting an absolute temperature from one in kelvin should give a difference", func() {
+			mockInput.tokenize("300 (K) 20 (degC) -")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Scale, ShouldEqual, quantity.ScaleInterval)
+			num, unit := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 6.85)
+			So(unit, ShouldResemble, quantity.UnitDisplayList{{Identifier: "K", Exponent: quantity.IntExponent(1)}})
+		})
+		Convey("absolute temperatures can not be divided by quantities with units", func() {
+			mockInput.tokenize("1 (degC) 1 (min) /")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrAbsoluteScale{})
+			So(mockInterpreter.StackDepth(), ShouldEqual, 2)
+			So(top().Scale, ShouldEqual, quantity.ScaleRatio)
+
+			mockInput.tokenize("r 2 *")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+		})
+		Convey("absolute temperatures converted to kelvin can be multiplied", func() {
+			mockInput.tokenize("20 (degC) (K)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(top().Scale, ShouldEqual, quantity.ScaleRatio)
+
+			mockInput.tokenize("2 (mol) *")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Number.Float64(), ShouldAlmostEqual, 586.3)
+
+			Convey("and are absolute again in degC", func() {
+				mockInput.tokenize("2 (mol) / (degC)")
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors)
+				So(top().Scale, ShouldEqual, quantity.ScaleAbsolute)
+				num, _ := top().Format()
+				So(num.Float64(), ShouldAlmostEqual, 20)
+			})
+		})
+		Convey("absolute temperatures can not be converted to differences", func() {
+			mockInput.tokenize("20 (degC) (ΔdegC)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrAbsoluteScale{})
+		})
+		Convey("differences can not be converted to absolute temperatures", func() {
+			mockInput.tokenize("5 (ΔdegC) (degC)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrAbsoluteScale{})
+			So(top().Scale, ShouldEqual, quantity.ScaleInterval)
+		})
+		Convey("adding a difference to a temperature in kelvin should give a temperature", func() {
+			mockInput.tokenize("300 (K) 5 (ΔdegC) +")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Scale, ShouldEqual, quantity.ScaleRatio)
+			num, unit := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 305)
+			So(unit, ShouldResemble, quantity.UnitDisplayList{{Identifier: "K", Exponent: quantity.IntExponent(1)}})
+
+			mockInput.tokenize("(degC)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Scale, ShouldEqual, quantity.ScaleAbsolute)
+			num, _ = top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 31.85)
+
+			mockInput.tokenize("300 (K) 5 (ΔdegC) -")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(top().Scale, ShouldEqual, quantity.ScaleRatio)
+			So(top().Number.Float64(), ShouldAlmostEqual, 295)
+		})
+		Convey("offset units should act as differences in compound units", func() {
+			mockInput.tokenize("2 (K) 1 (s) / (degF)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, unit := top().Format()
+			So(num.Float64(), ShouldAlmostEqual, 3.6)
+			So(unit, ShouldResemble, quantity.UnitDisplayList{
+				{Identifier: "degF", Exponent: quantity.IntExponent(1)},
+				{Identifier: "s", Exponent: quantity.IntExponent(-1)},
+			})
+		})
+	})
+}
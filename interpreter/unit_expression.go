@@ -0,0 +1,77 @@
+package interpreter
+
+import (
+	"math/big"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+	"github.com/eternal-flame-ad/unitdc/syntax"
+)
+
+// resolveUnitExpression looks up the units of expr, and finds the combination of base units of expr,
+// the value of one of expr in these base units, and the derived units to display expr with.
+//
+// Units with an offset are taken as differences, like (degC/min).
+func (s *State) resolveUnitExpression(expr syntax.UnitExpression) (comb quantity.UCombination, multiplier quantity.Number, display quantity.UDerivedList, err error) {
+	// the multiplier is exact, so that the operand is rounded only once
+	multiplier = quantity.NewRat(big.NewRat(1, 1))
+	for _, f := range expr {
+		if f.Identifier == "1" {
+			continue
+		}
+		exp := quantity.NewExponent(f.Num, f.Den)
+		base, derived := s.Registry.Lookup(f.Identifier)
+		switch {
+		case base != nil:
+			comb = append(comb, quantity.UExp{Unit: *base, Exponent: exp})
+		case derived != nil:
+			if derived.IsLogarithmic() {
+				err = ErrIncompatibleUnit{OffendingUnit: derived.UnitExponents}
+				return
+			}
+			c := derived.UnitExponents.Clone()
+			c.Pow(exp)
+			comb = append(comb, c...)
+			multiplier = multiplier.Mul(quantity.PowNumber(quantity.NewRat(derived.ExactMultiplier()), exp))
+			if !derived.UnitExponents.IsNoUnit() {
+				display = append(display, *derived)
+			}
+		default:
+			err = ErrUnknownUnit{f.Identifier}
+			return
+		}
+	}
+	comb.Simplify()
+	return
+}
+
+// convertUnitExpression applies a unit expression like (mg/ml) on operand, see OperatorUnitConvert.
+//
+// Numbers without a unit are tagged with the expression, other quantities must be of the same kind
+// and are displayed in the units of the expression.
+func (s *State) convertUnitExpression(operand *quantity.Q, bare bool, unitTok syntax.TokenUnit) (err error) {
+	var expr syntax.UnitExpression
+	expr, err = unitTok.UnitExpression()
+	if err != nil {
+		return
+	}
+	comb, multiplier, display, err := s.resolveUnitExpression(expr)
+	if err != nil {
+		return
+	}
+
+	if operand.UnitExponents.IsNoUnit() && (bare || !comb.IsNoUnit()) {
+		v := operand.Number
+		operand.Number = s.Numbers.Convert(v.Mul(multiplier))
+		operand.Uncertainty *= multiplier.Float64()
+		operand.UnitExponents = comb
+		defer func() {
+			err = s.checkExact(unitTok.Literal, v, operand.Number)
+		}()
+	} else if !operand.UnitExponents.Equal(&comb) {
+		err = ErrIncompatibleUnit{TargetUnit: comb, OffendingUnit: operand.UnitExponents}
+		return
+	}
+	operand.DerivedUnitsToUse = display
+	operand.ExplicitUnits = !bare
+	return
+}
@@ -0,0 +1,148 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/eternal-flame-ad/unitdc/quantity"
+	"github.com/eternal-flame-ad/unitdc/syntax"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitLookup(t *testing.T) {
+	Convey("Test Unit Lookup", t, func() {
+		mockInput, mockOutput, mockInterpreter, _ := newMockedState()
+
+		Convey("should resolve any SI prefix on demand", func() {
+			cases := []struct {
+				Unit   string
+				Number float64
+				Base   quantity.UCombination
+			}{
+				{"(kg)", 1e3, quantity.UCombination{{Unit: quantity.UnitGram, Exponent: quantity.IntExponent(1)}}},
+				{"(fmol)", 1e-15, quantity.UCombination{{Unit: quantity.UnitMole, Exponent: quantity.IntExponent(1)}}},
+				{"(Ml)", 1e6, quantity.UCombination{{Unit: quantity.UnitLiter, Exponent: quantity.IntExponent(1)}}},
+				{"(µg)", 1e-6, quantity.UCombination{{Unit: quantity.UnitGram, Exponent: quantity.IntExponent(1)}}},
+				{"(ug)", 1e-6, quantity.UCombination{{Unit: quantity.UnitGram, Exponent: quantity.IntExponent(1)}}},
+				{"(dam)", 1e1, quantity.UCombination{{Unit: quantity.UnitMeter, Exponent: quantity.IntExponent(1)}}},
+				{"(GHz)", 1e9, quantity.UnitDerivedHertz.UnitExponents},
+				{"(mM)", 1e-3, quantity.UnitDerivedMolar.UnitExponents},
+				{"(kDa)", 1e3, quantity.UnitDerivedAmu.UnitExponents},
+			}
+			for _, c := range cases {
+				mockInterpreter.StackClear()
+				mockInput.inputTokens = []syntax.Token{
+					&syntax.TokenNumeric{Literal: "1"},
+					&syntax.TokenUnit{Literal: c.Unit},
+				}
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors)
+				q := mockInterpreter.Stack[mockInterpreter.StackPointer]
+				So(q.Number.Float64(), ShouldAlmostEqual, c.Number, c.Number*1e-9)
+				So(q.UnitExponents.Equal(&c.Base), ShouldBeTrue)
+				So(q.DerivedUnitsToUse, ShouldHaveLength, 1)
+				So(q.DerivedUnitsToUse[0].Identifier, ShouldEqual, c.Unit[1:len(c.Unit)-1])
+			}
+		})
+		Convey("should resolve aliases", func() {
+			mockInput.tokenize("1 (kΩ) 20 (°C) 5 (Δ°C) +")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockInterpreter.StackDepth(), ShouldEqual, 2)
+			So(mockInterpreter.Stack[mockInterpreter.StackPointer].Number.Float64(), ShouldAlmostEqual, 298.15)
+			So(mockInterpreter.Stack[0].DerivedUnitsToUse[0].Identifier, ShouldEqual, "kohm")
+		})
+		Convey("should prefer registered units over prefixed readings", func() {
+			mockInput.inputTokens = []syntax.Token{
+				&syntax.TokenNumeric{Literal: "1"},
+				&syntax.TokenUnit{Literal: "(mol)"},
+			}
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			So(mockInterpreter.Stack[mockInterpreter.StackPointer].UnitExponents, ShouldResemble,
+				quantity.UCombination{{Unit: quantity.UnitMole, Exponent: quantity.IntExponent(1)}})
+		})
+		Convey("should not prefix an already prefixed unit", func() {
+			mockInput.inputTokens = []syntax.Token{
+				&syntax.TokenNumeric{Literal: "1"},
+				&syntax.TokenUnit{Literal: "(kmg)"},
+			}
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrUnknownUnit{})
+		})
+	})
+}
+
+func TestUnitExpressions(t *testing.T) {
+	Convey("Unit expressions", t, func() {
+		mockInput, mockOutput, mockInterpreter, top := newMockedState()
+		display := func(q quantity.Q) (float64, quantity.UnitDisplayList) {
+			num, units := q.Format()
+			return num.Float64(), units
+		}
+
+		Convey("should tag numbers", func() {
+			cases := []struct {
+				Source string
+				Number float64
+				Base   quantity.UCombination
+			}{
+				{"5 (mg/ml)", 5, quantity.UCombination{
+					{Unit: quantity.UnitGram, Exponent: quantity.IntExponent(1)},
+					{Unit: quantity.UnitLiter, Exponent: quantity.IntExponent(-1)},
+				}},
+				{"2 (m^2)", 2, quantity.UCombination{{Unit: quantity.UnitMeter, Exponent: quantity.IntExponent(2)}}},
+				{"3 (kg*m/s^2)", 3000, quantity.UnitDerivedNewton.UnitExponents},
+				{"1 (µmol/(l*min))", 1e-6 / 60, quantity.UCombination{
+					{Unit: quantity.UnitMole, Exponent: quantity.IntExponent(1)},
+					{Unit: quantity.UnitLiter, Exponent: quantity.IntExponent(-1)},
+					{Unit: quantity.UnitSecond, Exponent: quantity.IntExponent(-1)},
+				}},
+				{"60 (1/min)", 1, quantity.UnitDerivedHertz.UnitExponents},
+			}
+			for _, c := range cases {
+				mockInput.tokenize("c " + c.Source)
+				So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+				So(mockOutput, ShouldExpectOutputErrors)
+				So(top().Number.Float64(), ShouldAlmostEqual, c.Number, c.Number*1e-9)
+				comb := top().UnitExponents
+				So(comb.Equal(&c.Base), ShouldBeTrue)
+			}
+		})
+		Convey("should display quantities in the units of the expression", func() {
+			mockInput.tokenize("1 (ml) 1 (h) / (ul/min)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, units := display(top())
+			So(num, ShouldAlmostEqual, 1000./60)
+			So(units, ShouldResemble, quantity.UnitDisplayList{
+				{Identifier: "ul", Exponent: quantity.IntExponent(1)},
+				{Identifier: "min", Exponent: quantity.IntExponent(-1)},
+			})
+
+			mockInput.tokenize("c 5 (%w/v) (mg/ml)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors)
+			num, units = display(top())
+			So(num, ShouldAlmostEqual, 50)
+			So(units, ShouldResemble, quantity.UnitDisplayList{
+				{Identifier: "mg", Exponent: quantity.IntExponent(1)},
+				{Identifier: "ml", Exponent: quantity.IntExponent(-1)},
+			})
+		})
+		Convey("should refuse quantities of another kind", func() {
+			mockInput.tokenize("1 (ml) (mg/ml)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrIncompatibleUnit{})
+			So(top().DerivedUnitsToUse[0].Identifier, ShouldEqual, "ml")
+		})
+		Convey("should report unknown units and syntax errors", func() {
+			mockInput.tokenize("1 (mg/foo)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, ErrUnknownUnit{})
+
+			mockInput.tokenize("(mg//ml)")
+			So(mockInterpreter.HandleTokensFromInput(), ShouldBeNil)
+			So(mockOutput, ShouldExpectOutputErrors, syntax.ErrUnitExpression{})
+		})
+	})
+}
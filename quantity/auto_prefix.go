@@ -0,0 +1,106 @@
+package quantity
+
+import (
+	"math"
+	"math/big"
+)
+
+// autoPrefixIndex finds the unit to prefix in fits: the first unit with exponent 1,
+// or else the first unit with a positive exponent, -1 if there is none.
+// Dimensionless units like (%) are never prefixed.
+func autoPrefixIndex(fits []derivedPower) int {
+	for i, f := range fits {
+		if f.exp == IntExponent(1) && f.unit.Offset == 0 && !f.unit.UnitExponents.IsNoUnit() {
+			return i
+		}
+	}
+	for i, f := range fits {
+		if f.exp.Sign() > 0 && f.unit.Offset == 0 && !f.unit.UnitExponents.IsNoUnit() {
+			return i
+		}
+	}
+	return -1
+}
+
+// engineeringPrefixes lists the engineering prefixes among prefixes, one per multiplier,
+// and no prefix at all
+func engineeringPrefixes(prefixes []Prefix) []Prefix {
+	res := []Prefix{{Symbol: "", Multiplier: 1}}
+	for _, p := range prefixes {
+		if !p.IsEngineering() {
+			continue
+		}
+		duplicate := false
+		for _, p2 := range res {
+			if p2.Multiplier == p.Multiplier {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// autoPrefix changes the prefix of one unit in fits so that the displayed number is in [1, 1000),
+// or the closest possible with the prefixes accepted by the unit.
+//
+// num and uncertainty are displayed in fits, the adjusted number and uncertainty are returned.
+func autoPrefix(num Number, uncertainty float64, fits []derivedPower, prefixesOf func(identifier string) []Prefix) (Number, float64) {
+	value := math.Abs(num.Float64())
+	if value == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return num, uncertainty
+	}
+	i := autoPrefixIndex(fits)
+	if i < 0 {
+		return num, uncertainty
+	}
+
+	current := fits[i].unit.Prefix
+	if current.Symbol == "" {
+		current.Multiplier = 1
+	}
+	unprefixed := fits[i].unit.Unprefixed()
+	exp := fits[i].exp.Float64()
+	unprefixedValue := value * math.Pow(current.Multiplier, exp)
+
+	var prefixes []Prefix
+	if prefixesOf == nil {
+		prefixes = engineeringPrefixes(SIPrefixes)
+	} else {
+		prefixes = engineeringPrefixes(prefixesOf(unprefixed.Identifier))
+	}
+
+	// the largest prefix not exceeding the value, or the smallest prefix if all of them do
+	var best, smallest Prefix
+	for _, p := range prefixes {
+		if smallest.Multiplier == 0 || p.Multiplier < smallest.Multiplier {
+			smallest = p
+		}
+		if math.Pow(p.Multiplier, exp) <= unprefixedValue*(1+1e-9) && p.Multiplier > best.Multiplier {
+			best = p
+		}
+	}
+	if best.Multiplier == 0 {
+		best = smallest
+	}
+	if best.Multiplier == current.Multiplier {
+		return num, uncertainty
+	}
+
+	if best.Symbol == "" {
+		fits[i].unit = unprefixed
+	} else {
+		fits[i].unit = best.ApplyDerived(unprefixed)
+	}
+	if fits[i].exp.IsInteger() {
+		num = mulExact(num, ratPow(new(big.Rat).Quo(decimalRat(current.Multiplier), decimalRat(best.Multiplier)), fits[i].exp.Num()))
+	} else {
+		num = num.Mul(PowNumber(num.convert(Float(current.Multiplier)), fits[i].exp)).
+			Quo(PowNumber(num.convert(Float(best.Multiplier)), fits[i].exp))
+	}
+	uncertainty *= math.Pow(current.Multiplier/best.Multiplier, exp)
+	return num, uncertainty
+}
@@ -0,0 +1,73 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAutoPrefix(t *testing.T) {
+	Convey("Automatic engineering prefixes", t, func() {
+		registry := NewDefaultRegistry()
+		opts := registry.FormatOptions(false, true)
+		format := func(q Q) (float64, []string) {
+			num, units := q.FormatWith(opts)
+			var res []string
+			for _, u := range units {
+				res = append(res, u.Identifier+u.Exponent.String())
+			}
+			return num.Float64(), res
+		}
+		_, nanoliter := registry.Lookup("nl")
+
+		Convey("should choose the prefix of base units", func() {
+			num, units := format(Q{Number: Float(2.5e-5), UnitExponents: UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}}})
+			So(num, ShouldAlmostEqual, 25)
+			So(units, ShouldResemble, []string{"µg1"})
+
+			num, units = format(Q{Number: Float(1000), UnitExponents: UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}}})
+			So(num, ShouldAlmostEqual, 1)
+			So(units, ShouldResemble, []string{"kg1"})
+		})
+		Convey("should replace the prefix of preferred units", func() {
+			q := Q{
+				Number:            Float(125e-6),
+				UnitExponents:     UCombination{{Unit: UnitLiter, Exponent: IntExponent(1)}},
+				DerivedUnitsToUse: UDerivedList{*nanoliter},
+			}
+			num, units := format(q)
+			So(num, ShouldAlmostEqual, 125)
+			So(units, ShouldResemble, []string{"µl1"})
+		})
+		Convey("should keep explicit units", func() {
+			q := Q{
+				Number:            Float(125e-6),
+				UnitExponents:     UCombination{{Unit: UnitLiter, Exponent: IntExponent(1)}},
+				DerivedUnitsToUse: UDerivedList{*nanoliter},
+				ExplicitUnits:     true,
+			}
+			num, units := format(q)
+			So(num, ShouldAlmostEqual, 125000)
+			So(units, ShouldResemble, []string{"nl1"})
+		})
+		Convey("should prefix the numerator", func() {
+			q := Q{Number: Float(2e-3), UnitExponents: UCombination{
+				{Unit: UnitMole, Exponent: IntExponent(1)},
+				{Unit: UnitLiter, Exponent: IntExponent(-1)},
+			}}
+			num, units := format(q)
+			So(num, ShouldAlmostEqual, 2)
+			So(units, ShouldResemble, []string{"mmol1", "l-1"})
+		})
+		Convey("should scale the uncertainty", func() {
+			q := Q{Number: Float(2.5e-5), Uncertainty: 1e-6, UnitExponents: UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}}}
+			d := q.DisplayWith(opts)
+			So(d.Uncertainty, ShouldAlmostEqual, 1)
+		})
+		Convey("should leave zero alone", func() {
+			num, units := format(Q{Number: Float(0), UnitExponents: UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}}})
+			So(num, ShouldEqual, 0)
+			So(units, ShouldResemble, []string{"g1"})
+		})
+	})
+}
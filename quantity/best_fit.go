@@ -0,0 +1,197 @@
+package quantity
+
+// maxBestFitUnits is the maximum number of derived units combined in a best fit
+const maxBestFitUnits = 2
+
+// bestFitExponents are the exponents tried for each derived unit, in order of preference
+var bestFitExponents = []Exponent{
+	IntExponent(1), IntExponent(-1),
+	IntExponent(2), IntExponent(-2),
+	IntExponent(3), IntExponent(-3),
+}
+
+// derivedPower is a derived unit raised to an exponent
+type derivedPower struct {
+	unit      UDerived
+	exp       Exponent
+	preferred bool
+}
+
+// fitCandidate is a derived unit that can be used in a best fit
+type fitCandidate struct {
+	unit UDerived
+	// preferred units may bring in base units that are not in the fitted combination
+	preferred bool
+}
+
+// fitCost ranks representations of a unit, fewer symbols first, then smaller exponents,
+// then more of the preferred units
+type fitCost struct {
+	symbols   int
+	exponents Exponent
+	preferred int
+}
+
+func (c fitCost) less(c2 fitCost) bool {
+	if c.symbols != c2.symbols {
+		return c.symbols < c2.symbols
+	}
+	if c.exponents != c2.exponents {
+		return c.exponents.Less(c2.exponents)
+	}
+	return c.preferred > c2.preferred
+}
+
+func absExponent(e Exponent) Exponent {
+	if e.Sign() < 0 {
+		return e.Neg()
+	}
+	return e
+}
+
+func costOf(fits []derivedPower, remain UCombination) (res fitCost) {
+	res.symbols = len(fits) + len(remain)
+	for _, f := range fits {
+		res.exponents = res.exponents.Add(absExponent(f.exp))
+		if f.preferred {
+			res.preferred++
+		}
+	}
+	for _, u := range remain {
+		res.exponents = res.exponents.Add(absExponent(u.Exponent))
+	}
+	return
+}
+
+// divideUnits computes comb / d^exp
+func divideUnits(comb UCombination, d UCombination, exp Exponent) UCombination {
+	divisor := d.Clone()
+	divisor.Pow(exp.Neg())
+	res := append(comb.Clone(), divisor...)
+	res.Simplify()
+	return res
+}
+
+// introducesUnits reports whether next uses any base unit not in comb
+func introducesUnits(comb UCombination, next UCombination) bool {
+	for _, n := range next {
+		found := false
+		for _, c := range comb {
+			if c.Unit == n.Unit {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+	return false
+}
+
+// fitCandidates lists the units usable in a best fit, preferred units first,
+// keeping only the first unit of each combination of base units.
+//
+// Units with an offset, logarithmic and interval units are never used, ExplicitOnly units only if preferred.
+func fitCandidates(preferred UDerivedList, auto UDerivedList) (res []fitCandidate) {
+	add := func(d UDerived, isPreferred bool) {
+		if d.Offset != 0 || d.IsLogarithmic() || d.Interval || (d.ExplicitOnly && !isPreferred) || d.UnitExponents.IsNoUnit() {
+			return
+		}
+		for _, c := range res {
+			if c.unit.UnitExponents.Equal(&d.UnitExponents) {
+				return
+			}
+		}
+		res = append(res, fitCandidate{unit: d, preferred: isPreferred})
+	}
+	for _, d := range preferred {
+		add(d, true)
+	}
+	for _, d := range auto {
+		add(d, false)
+	}
+	return
+}
+
+// fitExponents lists the exponents tried for fitting comb with a derived unit.
+//
+// Units of a single base unit are also tried with fractions of the least common denominator
+// of the exponents in comb, so V/√Hz can be displayed as is.
+func fitExponents(comb UCombination, d UDerived) []Exponent {
+	if len(d.UnitExponents) != 1 {
+		return bestFitExponents
+	}
+	den := 1
+	for _, c := range comb {
+		den = den * c.Exponent.Den() / gcd(den, c.Exponent.Den())
+	}
+	if den == 1 {
+		return bestFitExponents
+	}
+	return append(bestFitExponents[:len(bestFitExponents):len(bestFitExponents)],
+		NewExponent(1, den), NewExponent(-1, den))
+}
+
+// bestFit searches the simplest representation of comb in terms of the candidate derived units
+// and the remaining base units.
+//
+// Representations with fewer symbols are preferred, then those with smaller exponents.
+// On ties, comb itself is preferred over derived units, and earlier candidates over later ones.
+// Derived units that are not preferred are only used if they do not bring in base units
+// that are not in comb, so (m)2(s)-2 is not displayed as (J)(g)-1.
+func bestFit(comb UCombination, candidates []fitCandidate) (res []derivedPower, remain UCombination) {
+	comb = comb.Clone()
+	comb.Simplify()
+	remain = comb
+	if len(comb) == 0 {
+		return
+	}
+
+	best := costOf(nil, comb)
+	var search func(fits []derivedPower, rest UCombination, start int)
+	search = func(fits []derivedPower, rest UCombination, start int) {
+		if len(fits) == maxBestFitUnits {
+			return
+		}
+		for i := start; i < len(candidates); i++ {
+			c := candidates[i]
+			if !c.unit.UnitExponents.HasOverlap(rest) {
+				continue
+			}
+			for _, exp := range fitExponents(comb, c.unit) {
+				next := divideUnits(rest, c.unit.UnitExponents, exp)
+				if !c.preferred && introducesUnits(rest, next) {
+					continue
+				}
+				nextFits := append(fits[:len(fits):len(fits)], derivedPower{unit: c.unit, exp: exp, preferred: c.preferred})
+				if cost := costOf(nextFits, next); cost.less(best) {
+					best = cost
+					res = nextFits
+					remain = next
+				}
+				search(nextFits, next, i+1)
+			}
+		}
+	}
+	search(nil, comb, 0)
+	return
+}
+
+// autoFit chooses derived units for displaying comb automatically.
+//
+// applied are the preferred units already derived from comb, leaving remain.
+// The result is the simpler of applied followed by the best fit of remain, and
+// the best fit of the whole comb with the preferred units tried first.
+func autoFit(comb UCombination, applied []derivedPower, remain UCombination, preferred UDerivedList, auto UDerivedList) ([]derivedPower, UCombination) {
+	candidates := fitCandidates(preferred, auto)
+
+	restFits, restRemain := bestFit(remain, candidates)
+	fits := append(applied[:len(applied):len(applied)], restFits...)
+
+	wholeFits, wholeRemain := bestFit(comb, candidates)
+	if costOf(wholeFits, wholeRemain).less(costOf(fits, restRemain)) {
+		return wholeFits, wholeRemain
+	}
+	return fits, restRemain
+}
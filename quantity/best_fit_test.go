@@ -0,0 +1,61 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBestFit(t *testing.T) {
+	Convey("Automatic derived units", t, func() {
+		opts := FormatOptions{AutoDerived: NewDefaultRegistry().DerivedUnits()}
+		unitsOf := func(q Q) (res []string) {
+			_, units := q.FormatWith(opts)
+			for _, u := range units {
+				res = append(res, u.Identifier+u.Exponent.String())
+			}
+			return
+		}
+		comb := func(exps ...UExp) Q {
+			return Q{Number: Float(1), UnitExponents: exps}
+		}
+		exp := func(u U, e int) UExp {
+			return UExp{Unit: u, Exponent: IntExponent(e)}
+		}
+
+		Convey("should find the simplest derived unit", func() {
+			So(unitsOf(comb(exp(UnitGram, 1), exp(UnitMole, -1))), ShouldResemble, []string{"Da1"})
+			So(unitsOf(comb(exp(UnitMole, 1), exp(UnitLiter, -1))), ShouldResemble, []string{"M1"})
+			So(unitsOf(comb(exp(UnitGram, 1), exp(UnitMeter, 2), exp(UnitSecond, -3))), ShouldResemble, []string{"W1"})
+			So(unitsOf(comb(exp(UnitGram, 1), exp(UnitMeter, 2), exp(UnitSecond, -2), exp(UnitMole, -1))),
+				ShouldResemble, []string{"J1", "mol-1"})
+		})
+		Convey("should convert the number", func() {
+			num, _ := comb(exp(UnitGram, 1), exp(UnitMeter, 1), exp(UnitSecond, -2)).FormatWith(opts)
+			So(num.Float64(), ShouldAlmostEqual, 1e-3)
+		})
+		Convey("should not choose explicit only units", func() {
+			So(unitsOf(comb(exp(UnitGram, 1), exp(UnitLiter, -1))), ShouldResemble, []string{"g1", "l-1"})
+		})
+		Convey("should keep base units when nothing is simpler", func() {
+			So(unitsOf(comb(exp(UnitSecond, -1))), ShouldResemble, []string{"s-1"})
+			So(unitsOf(comb(exp(UnitMeter, 1), exp(UnitSecond, -1))), ShouldResemble, []string{"m1", "s-1"})
+		})
+		Convey("should not bring in unrelated base units", func() {
+			So(unitsOf(comb(exp(UnitMeter, 2), exp(UnitSecond, -2))), ShouldResemble, []string{"m2", "s-2"})
+		})
+		Convey("should respect preferred units", func() {
+			q := comb(exp(UnitGram, 1), exp(UnitSecond, -3))
+			q.DerivedUnitsToUse = UDerivedList{UnitDerivedWatt}
+			So(unitsOf(q), ShouldResemble, []string{"W1", "m-2"})
+
+			q = comb(exp(UnitMeter, 2), exp(UnitSecond, -2))
+			q.DerivedUnitsToUse = UDerivedList{UnitDerivedGray}
+			So(unitsOf(q), ShouldResemble, []string{"Gy1"})
+		})
+		Convey("should be off by default", func() {
+			_, units := comb(exp(UnitGram, 1), exp(UnitMole, -1)).Format()
+			So(units, ShouldHaveLength, 2)
+		})
+	})
+}
@@ -0,0 +1,204 @@
+package quantity
+
+import "math/big"
+
+// Constant is a named physical constant
+type Constant struct {
+	Name        string
+	Description string
+	// Value is the value of the constant in the base units of UnitExponents
+	Value         *big.Rat
+	UnitExponents UCombination
+	// Uncertainty is the standard uncertainty of Value, zero for constants that are exact by definition
+	Uncertainty float64
+	// DerivedUnitsToUse are the units the constant is displayed in
+	DerivedUnitsToUse UDerivedList
+}
+
+// Q creates a quantity of the constant, in the number representation of numbers
+func (c Constant) Q(numbers NumberContext) Q {
+	return Q{
+		Number:            numbers.FromRat(c.Value),
+		Uncertainty:       c.Uncertainty,
+		UnitExponents:     c.UnitExponents.Clone(),
+		DerivedUnitsToUse: c.DerivedUnitsToUse.Clone(),
+	}
+}
+
+func mustParseRat(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic("invalid number " + s)
+	}
+	return r
+}
+
+// Physical constants, from the CODATA 2018 recommended values.
+//
+// Since the base unit of mass is the gram, the values of constants involving the kilogram
+// carry the corresponding multiplier, like 1.380649e-20 (g)(m)2(s)-2(K)-1 for 1.380649e-23 (J)(K)-1.
+// The constants defining the SI, and R and F derived from them, are exact.
+var (
+	ConstantAvogadro = Constant{
+		Name:        "NA",
+		Description: "Avogadro constant",
+		Value:       mustParseRat("6.02214076e23"),
+		UnitExponents: UCombination{
+			{Unit: UnitMole, Exponent: IntExponent(-1)},
+		},
+	}
+	ConstantBoltzmann = Constant{
+		Name:        "k",
+		Description: "Boltzmann constant",
+		Value:       mustParseRat("1.380649e-20"),
+		UnitExponents: UCombination{
+			{Unit: UnitGram, Exponent: IntExponent(1)},
+			{Unit: UnitMeter, Exponent: IntExponent(2)},
+			{Unit: UnitSecond, Exponent: IntExponent(-2)},
+			{Unit: UnitKelvin, Exponent: IntExponent(-1)},
+		},
+		DerivedUnitsToUse: UDerivedList{UnitDerivedJoule},
+	}
+	ConstantElementaryCharge = Constant{
+		Name:        "e",
+		Description: "elementary charge",
+		Value:       mustParseRat("1.602176634e-19"),
+		UnitExponents: UCombination{
+			{Unit: UnitAmpere, Exponent: IntExponent(1)},
+			{Unit: UnitSecond, Exponent: IntExponent(1)},
+		},
+		DerivedUnitsToUse: UDerivedList{UnitDerivedCoulomb},
+	}
+	ConstantGas = Constant{
+		Name:        "R",
+		Description: "molar gas constant",
+		Value:       new(big.Rat).Mul(ConstantAvogadro.Value, ConstantBoltzmann.Value),
+		UnitExponents: UCombination{
+			{Unit: UnitGram, Exponent: IntExponent(1)},
+			{Unit: UnitMeter, Exponent: IntExponent(2)},
+			{Unit: UnitSecond, Exponent: IntExponent(-2)},
+			{Unit: UnitKelvin, Exponent: IntExponent(-1)},
+			{Unit: UnitMole, Exponent: IntExponent(-1)},
+		},
+		DerivedUnitsToUse: UDerivedList{UnitDerivedJoule},
+	}
+	ConstantFaraday = Constant{
+		Name:        "F",
+		Description: "Faraday constant",
+		Value:       new(big.Rat).Mul(ConstantAvogadro.Value, ConstantElementaryCharge.Value),
+		UnitExponents: UCombination{
+			{Unit: UnitAmpere, Exponent: IntExponent(1)},
+			{Unit: UnitSecond, Exponent: IntExponent(1)},
+			{Unit: UnitMole, Exponent: IntExponent(-1)},
+		},
+		DerivedUnitsToUse: UDerivedList{UnitDerivedCoulomb},
+	}
+	ConstantPlanck = Constant{
+		Name:        "h",
+		Description: "Planck constant",
+		Value:       mustParseRat("6.62607015e-31"),
+		UnitExponents: UCombination{
+			{Unit: UnitGram, Exponent: IntExponent(1)},
+			{Unit: UnitMeter, Exponent: IntExponent(2)},
+			{Unit: UnitSecond, Exponent: IntExponent(-1)},
+		},
+	}
+	ConstantSpeedOfLight = Constant{
+		Name:        "c",
+		Description: "speed of light in vacuum",
+		Value:       mustParseRat("299792458"),
+		UnitExponents: UCombination{
+			{Unit: UnitMeter, Exponent: IntExponent(1)},
+			{Unit: UnitSecond, Exponent: IntExponent(-1)},
+		},
+	}
+	ConstantStandardGravity = Constant{
+		Name:        "gn",
+		Description: "standard acceleration of gravity",
+		Value:       mustParseRat("9.80665"),
+		UnitExponents: UCombination{
+			{Unit: UnitMeter, Exponent: IntExponent(1)},
+			{Unit: UnitSecond, Exponent: IntExponent(-2)},
+		},
+	}
+	ConstantGravitation = Constant{
+		Name:        "G",
+		Description: "Newtonian constant of gravitation",
+		Value:       mustParseRat("6.67430e-14"),
+		Uncertainty: 1.5e-18,
+		UnitExponents: UCombination{
+			{Unit: UnitMeter, Exponent: IntExponent(3)},
+			{Unit: UnitGram, Exponent: IntExponent(-1)},
+			{Unit: UnitSecond, Exponent: IntExponent(-2)},
+		},
+		DerivedUnitsToUse: UDerivedList{DeriveUnitWithEngineeringSymbol("k", UnitGram)},
+	}
+	ConstantElectronMass = Constant{
+		Name:        "me",
+		Description: "electron mass",
+		Value:       mustParseRat("9.1093837015e-28"),
+		Uncertainty: 2.8e-37,
+		UnitExponents: UCombination{
+			{Unit: UnitGram, Exponent: IntExponent(1)},
+		},
+		DerivedUnitsToUse: UDerivedList{DeriveUnitWithEngineeringSymbol("k", UnitGram)},
+	}
+	ConstantProtonMass = Constant{
+		Name:        "mp",
+		Description: "proton mass",
+		Value:       mustParseRat("1.67262192369e-24"),
+		Uncertainty: 5.1e-34,
+		UnitExponents: UCombination{
+			{Unit: UnitGram, Exponent: IntExponent(1)},
+		},
+		DerivedUnitsToUse: UDerivedList{DeriveUnitWithEngineeringSymbol("k", UnitGram)},
+	}
+	ConstantVacuumPermittivity = Constant{
+		Name:        "eps0",
+		Description: "vacuum electric permittivity",
+		Value:       mustParseRat("8.8541878128e-15"),
+		Uncertainty: 1.3e-24,
+		UnitExponents: UCombination{
+			{Unit: UnitGram, Exponent: IntExponent(-1)},
+			{Unit: UnitMeter, Exponent: IntExponent(-3)},
+			{Unit: UnitSecond, Exponent: IntExponent(4)},
+			{Unit: UnitAmpere, Exponent: IntExponent(2)},
+		},
+		DerivedUnitsToUse: UDerivedList{UnitDerivedFarad},
+	}
+)
+
+// BuiltinConstants lists all builtin physical constants
+var BuiltinConstants = []Constant{
+	ConstantAvogadro,
+	ConstantGas,
+	ConstantFaraday,
+	ConstantBoltzmann,
+	ConstantPlanck,
+	ConstantSpeedOfLight,
+	ConstantElementaryCharge,
+	ConstantStandardGravity,
+	ConstantGravitation,
+	ConstantElectronMass,
+	ConstantProtonMass,
+	ConstantVacuumPermittivity,
+}
+
+// LookupConstant finds a builtin constant by name, names are case sensitive like unit identifiers
+func LookupConstant(name string) (Constant, error) {
+	for _, c := range BuiltinConstants {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Constant{}, ErrUnknownConstant{Name: name}
+}
+
+// ConstantNames lists the names of all builtin constants
+func ConstantNames() []string {
+	res := make([]string, len(BuiltinConstants))
+	for i, c := range BuiltinConstants {
+		res[i] = c.Name
+	}
+	return res
+}
@@ -0,0 +1,53 @@
+package quantity
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConstants(t *testing.T) {
+	Convey("Physical constants", t, func() {
+		Convey("should have unique names", func() {
+			seen := make(map[string]bool)
+			for _, name := range ConstantNames() {
+				So(seen[name], ShouldBeFalse)
+				seen[name] = true
+			}
+		})
+		Convey("should derive R and F exactly", func() {
+			c, err := LookupConstant("R")
+			So(err, ShouldBeNil)
+			So(c.Uncertainty, ShouldEqual, 0)
+			So(c.Value.Cmp(mustParseRat("8314.46261815324")), ShouldEqual, 0)
+			c, err = LookupConstant("F")
+			So(err, ShouldBeNil)
+			So(c.Value.Cmp(mustParseRat("96485.3321233100184")), ShouldEqual, 0)
+		})
+		Convey("should be displayed in SI units", func() {
+			c, _ := LookupConstant("k")
+			num, units := c.Q(NumberContext{}).Format()
+			So(num.Float64(), ShouldAlmostEqual, 1.380649e-23, 1e-30)
+			So(units, ShouldResemble, UnitDisplayList{
+				{Identifier: "J", Exponent: IntExponent(1)},
+				{Identifier: "K", Exponent: IntExponent(-1)},
+			})
+
+			c, _ = LookupConstant("me")
+			d := c.Q(NumberContext{}).Display()
+			So(d.Number.Float64(), ShouldAlmostEqual, 9.1093837015e-31, 1e-40)
+			So(d.Uncertainty, ShouldAlmostEqual, 2.8e-40, 1e-45)
+			So(d.Units, ShouldResemble, UnitDisplayList{{Identifier: "kg", Exponent: IntExponent(1)}})
+		})
+		Convey("should keep exact values in rational mode", func() {
+			c, _ := LookupConstant("NA")
+			q := c.Q(NumberContext{Mode: NumberModeRational})
+			So(q.Number.(Rat).Rat().Cmp(mustParseRat("602214076000000000000000")), ShouldEqual, 0)
+		})
+		Convey("should reject unknown names", func() {
+			_, err := LookupConstant("na")
+			So(errors.As(err, &ErrUnknownConstant{}), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,282 @@
+package quantity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// Definitions is the content of a unit definition file, for example:
+//
+//	{
+//	    "prefixes": {
+//	        "binary": [{"symbol": "Ki", "multiplier": 1024}, {"symbol": "Mi", "multiplier": 1048576}]
+//	    },
+//	    "units": [
+//	        {"identifier": "cell"},
+//	        {"identifier": "B", "prefixes": ["SI", "binary"]}
+//	    ],
+//	    "derived": [
+//	        {"identifier": "bp", "multiplier": 650, "units": {"Da": 1}},
+//	        {"identifier": "OD600", "multiplier": 8e11, "units": {"cell": 1, "l": -1}, "prefixes": []}
+//	    ],
+//	    "aliases": [
+//	        {"alias": "cells", "identifier": "cell"}
+//	    ],
+//	    "substances": [
+//	        {"name": "heparin", "mass": 5.5e-6}
+//	    ]
+//	}
+//
+// Builtin unit sets listed in "requires" are loaded first, see UnitSets.
+//
+// Prefix sets are named lists of prefixes, SIPrefixSet is always defined.
+// Units accept SI prefixes when "prefixes" is omitted, and no prefix when it is an empty list.
+//
+// Derived units are defined in terms of any unit known at the time of definition,
+// including prefixed and other derived units. The multiplier defaults to 1.
+// Logarithmic units have a "log" factor, and their multiplier is the reference, like for pH:
+//
+//	{"identifier": "pH", "log": -1, "units": {"M": 1}}
+//
+// Base units without an "id" are assigned an unused ID.
+//
+// Substances define the mass in grams of one IU of the substance, and the unit for its IU,
+// like (iu:heparin), which can be used in derived units and aliases.
+type Definitions struct {
+	Requires []string `json:"requires,omitempty"`
+
+	Prefixes map[string][]Prefix `json:"prefixes,omitempty"`
+	Units    []UnitDefinition    `json:"units,omitempty"`
+	Derived  []DerivedDefinition `json:"derived,omitempty"`
+	Aliases  []Alias             `json:"aliases,omitempty"`
+
+	Substances []Substance `json:"substances,omitempty"`
+}
+
+// UnitDefinition defines a base unit
+type UnitDefinition struct {
+	Identifier string   `json:"identifier"`
+	ID         int      `json:"id,omitempty"`
+	Prefixes   []string `json:"prefixes"`
+}
+
+// DerivedDefinition defines a derived unit
+type DerivedDefinition struct {
+	Identifier string              `json:"identifier"`
+	Multiplier *float64            `json:"multiplier,omitempty"`
+	Offset     float64             `json:"offset,omitempty"`
+	Interval   bool                `json:"interval,omitempty"`
+	Log        float64             `json:"log,omitempty"`
+	Units      map[string]Exponent `json:"units"`
+	Prefixes   []string            `json:"prefixes"`
+
+	// ExplicitOnly units are not chosen automatically for display
+	ExplicitOnly bool `json:"explicitOnly,omitempty"`
+}
+
+// NewDerivedDefinition describes a derived unit in terms of its base units
+func NewDerivedDefinition(d UDerived) DerivedDefinition {
+	multiplier := d.Multiplier
+	res := DerivedDefinition{
+		Identifier: d.Identifier,
+		Multiplier: &multiplier,
+		Offset:     d.Offset,
+		Interval:   d.Interval,
+		Log:        d.Log,
+		Units:      make(map[string]Exponent, len(d.UnitExponents)),
+
+		ExplicitOnly: d.ExplicitOnly,
+	}
+	for _, ue := range d.UnitExponents {
+		res.Units[ue.Unit.Identifier] = ue.Exponent
+	}
+	return res
+}
+
+// ParseDefinitions reads a unit definition file
+func ParseDefinitions(r io.Reader) (defs Definitions, err error) {
+	var buf bytes.Buffer
+	dec := json.NewDecoder(io.TeeReader(r, &buf))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&defs); err != nil {
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			line, column := lineColumn(buf.Bytes(), syntaxErr.Offset)
+			err = ErrDefinition{Entry: fmt.Sprintf("%d:%d", line, column), Err: err}
+		} else if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+			line, column := lineColumn(buf.Bytes(), typeErr.Offset)
+			err = ErrDefinition{Entry: fmt.Sprintf("%d:%d", line, column), Err: err}
+		}
+		return
+	}
+	return
+}
+
+// lineColumn finds the position of the last byte read by the JSON decoder when it failed at offset
+func lineColumn(data []byte, offset int64) (line int, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset > 0 {
+		offset--
+	}
+	line = 1 + bytes.Count(data[:offset], []byte{'\n'})
+	column = int(offset) - bytes.LastIndexByte(data[:offset], '\n')
+	return
+}
+
+// LoadDefinitions reads a unit definition file and adds all definitions to the registry.
+//
+// Either all definitions are added, or none if any of them is invalid.
+func (r *Registry) LoadDefinitions(reader io.Reader) error {
+	defs, err := ParseDefinitions(reader)
+	if err != nil {
+		return err
+	}
+	return r.AddDefinitions(defs)
+}
+
+// AddDefinitions adds all definitions to the registry.
+//
+// Either all definitions are added, or none if any of them is invalid,
+// the error points at the offending entry.
+func (r *Registry) AddDefinitions(defs Definitions) error {
+	res := r.Clone()
+
+	for _, name := range defs.Requires {
+		if err := res.LoadUnitSet(name); err != nil {
+			return ErrDefinition{Entry: "requires", Err: err}
+		}
+	}
+
+	prefixSetNames := make([]string, 0, len(defs.Prefixes))
+	for name := range defs.Prefixes {
+		prefixSetNames = append(prefixSetNames, name)
+	}
+	sort.Strings(prefixSetNames)
+	for _, name := range prefixSetNames {
+		if err := res.AddPrefixSet(name, defs.Prefixes[name]); err != nil {
+			return ErrDefinition{Entry: fmt.Sprintf("prefixes.%s", name), Err: err}
+		}
+	}
+
+	for i, def := range defs.Units {
+		entry := fmt.Sprintf("units[%d] (%s)", i, def.Identifier)
+		prefixes, err := res.resolvePrefixSets(def.Prefixes)
+		if err != nil {
+			return ErrDefinition{Entry: entry, Err: err}
+		}
+		u := U{Identifier: def.Identifier, ID: def.ID}
+		if u.ID == 0 {
+			u.ID = res.NextID()
+		}
+		if err := res.AddUnitWithPrefixes(u, prefixes); err != nil {
+			return ErrDefinition{Entry: entry, Err: err}
+		}
+	}
+
+	for i, def := range defs.Substances {
+		entry := fmt.Sprintf("substances[%d] (%s)", i, def.Name)
+		if err := res.AddSubstance(def); err != nil {
+			return ErrDefinition{Entry: entry, Err: err}
+		}
+	}
+
+	for i, def := range defs.Derived {
+		entry := fmt.Sprintf("derived[%d] (%s)", i, def.Identifier)
+		prefixes, err := res.resolvePrefixSets(def.Prefixes)
+		if err != nil {
+			return ErrDefinition{Entry: entry, Err: err}
+		}
+		d, err := res.derive(def)
+		if err != nil {
+			return ErrDefinition{Entry: entry, Err: err}
+		}
+		if err := res.AddDerivedUnitWithPrefixes(d, prefixes); err != nil {
+			return ErrDefinition{Entry: entry, Err: err}
+		}
+	}
+
+	for i, def := range defs.Aliases {
+		entry := fmt.Sprintf("aliases[%d] (%s)", i, def.Alias)
+		if err := res.AddAlias(def.Alias, def.Identifier); err != nil {
+			return ErrDefinition{Entry: entry, Err: err}
+		}
+	}
+
+	*r = *res
+	return nil
+}
+
+// resolvePrefixSets finds all prefixes in the named sets, nil names means SI prefixes
+func (r *Registry) resolvePrefixSets(names []string) (res []Prefix, err error) {
+	if names == nil {
+		return SIPrefixes, nil
+	}
+	for _, name := range names {
+		prefixes, ok := r.PrefixSet(name)
+		if !ok {
+			return nil, ErrUnknownPrefixSet{Name: name}
+		}
+		res = append(res, prefixes...)
+	}
+	return
+}
+
+// derive expands the units of a derived unit definition into base units
+func (r *Registry) derive(def DerivedDefinition) (res UDerived, err error) {
+	res = UDerived{
+		Identifier: def.Identifier,
+		Multiplier: 1,
+		Offset:     def.Offset,
+		Interval:   def.Interval,
+		Log:        def.Log,
+
+		ExplicitOnly: def.ExplicitOnly,
+	}
+	if def.Multiplier != nil {
+		res.Multiplier = *def.Multiplier
+	}
+	if res.Multiplier <= 0 || math.IsInf(res.Multiplier, 0) || math.IsNaN(res.Multiplier) {
+		return res, ErrInvalidMultiplier{Multiplier: res.Multiplier}
+	}
+	identifiers := make([]string, 0, len(def.Units))
+	for identifier := range def.Units {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+	// exact is the exact multiplier, as long as all exponents of derived units are integers
+	exact := decimalRat(res.Multiplier)
+	for _, identifier := range identifiers {
+		exp := def.Units[identifier]
+		base, derived := r.Lookup(identifier)
+		switch {
+		case base != nil:
+			res.UnitExponents = append(res.UnitExponents, UExp{Unit: *base, Exponent: exp})
+		case derived != nil && derived.IsLogarithmic():
+			return res, ErrLogarithmicInDefinition{Identifier: identifier}
+		case derived != nil && derived.Offset == 0:
+			res.Multiplier *= math.Pow(derived.Multiplier, exp.Float64())
+			if m := derived.ExactMultiplier(); exact != nil && m != nil && exp.IsInteger() {
+				exact.Mul(exact, ratPow(m, exp.Num()))
+			} else {
+				exact = nil
+			}
+			comb := derived.UnitExponents.Clone()
+			comb.Pow(exp)
+			res.UnitExponents = append(res.UnitExponents, comb...)
+		case derived != nil:
+			return res, ErrOffsetInDefinition{Identifier: identifier}
+		default:
+			return res, ErrUnknownBaseUnit{Identifier: def.Identifier, BaseUnit: U{Identifier: identifier}}
+		}
+	}
+	res.UnitExponents.Simplify()
+	if offset := decimalRat(res.Offset); exact != nil && offset != nil {
+		res = res.WithExactFactors(exact, offset)
+	}
+	return
+}
@@ -0,0 +1,112 @@
+package quantity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testDefinitions = `{
+    "prefixes": {
+        "binary": [{"symbol": "Ki", "multiplier": 1024}]
+    },
+    "units": [
+        {"identifier": "cell"},
+        {"identifier": "B", "prefixes": ["SI", "binary"]}
+    ],
+    "derived": [
+        {"identifier": "bp", "multiplier": 650, "units": {"Da": 1}},
+        {"identifier": "OD600", "multiplier": 8e11, "units": {"cell": 1, "ml": -1}, "prefixes": []},
+        {"identifier": "rtHz", "units": {"Hz": "1/2"}}
+    ],
+    "aliases": [
+        {"alias": "cells", "identifier": "cell"}
+    ],
+    "substances": [
+        {"name": "heparin", "mass": 5.5e-6}
+    ]
+}`
+
+func TestDefinitions(t *testing.T) {
+	Convey("Unit definition files", t, func() {
+		r := NewDefaultRegistry()
+
+		Convey("should load all kinds of definitions", func() {
+			nextID := r.NextID()
+			So(r.LoadDefinitions(strings.NewReader(testDefinitions)), ShouldBeNil)
+
+			cell, _ := r.LookupExact("cells")
+			So(cell.Identifier, ShouldEqual, "cell")
+			So(cell.ID, ShouldEqual, nextID)
+
+			_, kib := r.Lookup("KiB")
+			So(kib.Multiplier, ShouldEqual, 1024)
+			_, kb := r.Lookup("kB")
+			So(kb.Multiplier, ShouldEqual, 1000)
+			base, derived := r.Lookup("Kicell")
+			So(base, ShouldBeNil)
+			So(derived, ShouldBeNil)
+
+			_, bp := r.Lookup("kbp")
+			So(bp.Multiplier, ShouldAlmostEqual, 650e3)
+			So(bp.UnitExponents.Equal(&UnitDerivedAmu.UnitExponents), ShouldBeTrue)
+
+			_, od := r.LookupExact("OD600")
+			So(od.Multiplier, ShouldAlmostEqual, 8e14)
+			odComb := UCombination{
+				{Unit: UnitLiter, Exponent: IntExponent(-1)},
+				{Unit: *cell, Exponent: IntExponent(1)},
+			}
+			So(od.UnitExponents.Equal(&odComb), ShouldBeTrue)
+			base, derived = r.Lookup("mOD600")
+			So(base, ShouldBeNil)
+			So(derived, ShouldBeNil)
+
+			_, rtHz := r.LookupExact("rtHz")
+			So(rtHz.UnitExponents, ShouldResemble, UCombination{{Unit: UnitSecond, Exponent: NewExponent(-1, 2)}})
+
+			heparin, _ := r.LookupExact("iu:heparin")
+			substance, ok := r.Substance(*heparin)
+			So(ok, ShouldBeTrue)
+			So(substance.Mass, ShouldEqual, 5.5e-6)
+		})
+		Convey("errors should point at the offending entry", func() {
+			cases := []struct {
+				Source string
+				Entry  string
+				Err    error
+			}{
+				{`{"units": [{"identifier": "cell"}, {"identifier": "g"}]}`, "units[1] (g)", ErrDuplicateUnit{Identifier: "g"}},
+				{`{"units": [{"identifier": "cell", "prefixes": ["bin"]}]}`, "units[0] (cell)", ErrUnknownPrefixSet{Name: "bin"}},
+				{`{"derived": [{"identifier": "x", "units": {"furlong": 1}}]}`, "derived[0] (x)",
+					ErrUnknownBaseUnit{Identifier: "x", BaseUnit: U{Identifier: "furlong"}}},
+				{`{"derived": [{"identifier": "x", "multiplier": 0, "units": {"g": 1}}]}`, "derived[0] (x)", ErrInvalidMultiplier{}},
+				{`{"derived": [{"identifier": "x", "units": {"degC": 1}}]}`, "derived[0] (x)", ErrOffsetInDefinition{Identifier: "degC"}},
+				{`{"aliases": [{"alias": "x", "identifier": "y"}]}`, "aliases[0] (x)", ErrUnknownAliasTarget{Alias: "x", Identifier: "y"}},
+				{`{"prefixes": {"SI": []}}`, "prefixes.SI", ErrDuplicatePrefixSet{Name: "SI"}},
+				{"{\n  \"units\": [\n    {\"identifier\": 1}\n  ]\n}", "3:20", nil},
+				{"{\n  \"units\": [,]\n}", "2:13", nil},
+			}
+			for _, c := range cases {
+				err := r.LoadDefinitions(strings.NewReader(c.Source))
+				var defErr ErrDefinition
+				So(errors.As(err, &defErr), ShouldBeTrue)
+				So(defErr.Entry, ShouldEqual, c.Entry)
+				if c.Err != nil {
+					So(defErr.Err, ShouldResemble, c.Err)
+				}
+			}
+		})
+		Convey("failed loads should not modify the registry", func() {
+			err := r.LoadDefinitions(strings.NewReader(`{"units": [{"identifier": "cell"}, {"identifier": "g"}]}`))
+			So(err, ShouldNotBeNil)
+			base, _ := r.LookupExact("cell")
+			So(base, ShouldBeNil)
+		})
+		Convey("unknown fields should be rejected", func() {
+			So(r.LoadDefinitions(strings.NewReader(`{"derived": [{"identifier": "x", "multipler": 2}]}`)), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,165 @@
+package quantity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Exponent is a rational exponent of a unit, like the -1/2 in V/√Hz.
+//
+// Exponents are always kept in lowest terms with a positive denominator,
+// so they can be compared with ==. The zero value is the exponent 0.
+type Exponent struct {
+	num int
+	// den is the denominator, 0 for integer exponents so that the zero value equals IntExponent(0)
+	den int
+}
+
+// IntExponent creates an integer exponent
+func IntExponent(n int) Exponent {
+	return Exponent{num: n}
+}
+
+// NewExponent creates the exponent num/den, panics if den is zero
+func NewExponent(num int, den int) Exponent {
+	if den == 0 {
+		panic("zero denominator in unit exponent")
+	}
+	if den < 0 {
+		num, den = -num, -den
+	}
+	g := gcd(num, den)
+	if den/g == 1 {
+		return IntExponent(num / g)
+	}
+	return Exponent{num: num / g, den: den / g}
+}
+
+func gcd(a int, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a == 0 {
+		return 1
+	}
+	return a
+}
+
+// Num is the numerator of the exponent
+func (e Exponent) Num() int {
+	return e.num
+}
+
+// Den is the denominator of the exponent, which is always positive
+func (e Exponent) Den() int {
+	if e.den == 0 {
+		return 1
+	}
+	return e.den
+}
+
+func (e Exponent) Add(e2 Exponent) Exponent {
+	return NewExponent(e.num*e2.Den()+e2.num*e.Den(), e.Den()*e2.Den())
+}
+
+func (e Exponent) Sub(e2 Exponent) Exponent {
+	return e.Add(e2.Neg())
+}
+
+func (e Exponent) Mul(e2 Exponent) Exponent {
+	return NewExponent(e.num*e2.num, e.Den()*e2.Den())
+}
+
+// Div divides the exponent by e2, panics if e2 is zero
+func (e Exponent) Div(e2 Exponent) Exponent {
+	return NewExponent(e.num*e2.Den(), e.Den()*e2.num)
+}
+
+func (e Exponent) Neg() Exponent {
+	return Exponent{num: -e.num, den: e.den}
+}
+
+// Trunc returns the integer part of the exponent, rounded towards zero
+func (e Exponent) Trunc() Exponent {
+	return IntExponent(e.num / e.Den())
+}
+
+func (e Exponent) IsZero() bool {
+	return e.num == 0
+}
+
+func (e Exponent) IsInteger() bool {
+	return e.Den() == 1
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of the exponent
+func (e Exponent) Sign() int {
+	switch {
+	case e.num > 0:
+		return 1
+	case e.num < 0:
+		return -1
+	}
+	return 0
+}
+
+// Less reports whether e < e2
+func (e Exponent) Less(e2 Exponent) bool {
+	return e.num*e2.Den() < e2.num*e.Den()
+}
+
+func (e Exponent) Float64() float64 {
+	return float64(e.num) / float64(e.Den())
+}
+
+func (e Exponent) String() string {
+	if e.IsInteger() {
+		return strconv.Itoa(e.num)
+	}
+	return strconv.Itoa(e.num) + "/" + strconv.Itoa(e.Den())
+}
+
+// ParseExponent parses an integer or fractional exponent, like -2 or 1/2
+func ParseExponent(s string) (Exponent, error) {
+	numStr, denStr := s, "1"
+	if i := strings.IndexRune(s, '/'); i >= 0 {
+		numStr, denStr = s[:i], s[i+1:]
+	}
+	num, err := strconv.Atoi(strings.TrimSpace(numStr))
+	if err != nil {
+		return Exponent{}, fmt.Errorf("invalid exponent %q", s)
+	}
+	den, err := strconv.Atoi(strings.TrimSpace(denStr))
+	if err != nil || den == 0 {
+		return Exponent{}, fmt.Errorf("invalid exponent %q", s)
+	}
+	return NewExponent(num, den), nil
+}
+
+// MarshalJSON encodes integer exponents as numbers and fractional exponents as strings like "1/2"
+func (e Exponent) MarshalJSON() ([]byte, error) {
+	if e.IsInteger() {
+		return json.Marshal(e.num)
+	}
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON accepts both integers and strings like "1/2"
+func (e *Exponent) UnmarshalJSON(data []byte) (err error) {
+	var n int
+	if json.Unmarshal(data, &n) == nil {
+		*e = IntExponent(n)
+		return nil
+	}
+	var s string
+	if err = json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid exponent %s", data)
+	}
+	*e, err = ParseExponent(s)
+	return
+}
@@ -0,0 +1,38 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestExponent(t *testing.T) {
+	Convey("Rational exponents", t, func() {
+		Convey("should be kept in lowest terms", func() {
+			So(NewExponent(2, 4), ShouldResemble, NewExponent(1, 2))
+			So(NewExponent(3, -6), ShouldResemble, NewExponent(-1, 2))
+			So(NewExponent(4, 2), ShouldResemble, IntExponent(2))
+			So(NewExponent(0, 5), ShouldResemble, IntExponent(0))
+		})
+		Convey("should compare with ==", func() {
+			So(Exponent{} == IntExponent(0), ShouldBeTrue)
+			So(NewExponent(0, 5) == Exponent{}, ShouldBeTrue)
+			So(NewExponent(2, 4).Add(NewExponent(1, 2)) == IntExponent(1), ShouldBeTrue)
+			So(IntExponent(1).Neg() == IntExponent(-1), ShouldBeTrue)
+		})
+		Convey("should do arithmetic", func() {
+			half := NewExponent(1, 2)
+			So(half.Add(half), ShouldResemble, IntExponent(1))
+			So(half.Sub(IntExponent(1)), ShouldResemble, NewExponent(-1, 2))
+			So(half.Mul(IntExponent(3)), ShouldResemble, NewExponent(3, 2))
+			So(IntExponent(1).Div(NewExponent(-1, 2)), ShouldResemble, IntExponent(-2))
+			So(NewExponent(-3, 2).Trunc(), ShouldResemble, IntExponent(-1))
+			So(half.Less(IntExponent(1)), ShouldBeTrue)
+			So(NewExponent(-1, 2).Less(NewExponent(-1, 3)), ShouldBeTrue)
+		})
+		Convey("should format", func() {
+			So(IntExponent(-2).String(), ShouldEqual, "-2")
+			So(NewExponent(-1, 2).String(), ShouldEqual, "-1/2")
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package quantity
+
+import (
+	"math"
+	"strconv"
+)
+
+// NewLogarithmicUnit creates a logarithmic unit, where a quantity x is log * log10(x / reference)
+// and reference is a multiple of the combination of base units.
+//
+// Example: pH is -1 log10 of a concentration relative to 1 (mol)(l)-1, and dB is 10 log10 of a ratio.
+//
+// Quantities are stored as their linear value, so arithmetic on them is on that value:
+// a pKa divided by a pH is the ratio Ka / [H+].
+func NewLogarithmicUnit(identifier string, log float64, reference float64, comb UCombination) UDerived {
+	res := NewUDerived(identifier, reference, comb)
+	res.Log = log
+	return res
+}
+
+// IsLogarithmic tells whether the unit is logarithmic
+func (u UDerived) IsLogarithmic() bool {
+	return u.Log != 0
+}
+
+// FromLog converts a value in a logarithmic unit into a number in base units
+func (u UDerived) FromLog(v float64) float64 {
+	return u.Multiplier * math.Pow(10, v/u.Log)
+}
+
+// FromLogUncertainty propagates the uncertainty of a value in a logarithmic unit into base units
+func (u UDerived) FromLogUncertainty(v float64, uv float64) float64 {
+	if uv == 0 {
+		return 0
+	}
+	return u.FromLog(v) * math.Ln10 * uv / math.Abs(u.Log)
+}
+
+// ToLog converts a number in base units into a value in a logarithmic unit
+func (u UDerived) ToLog(x float64) float64 {
+	return u.Log * math.Log10(x/u.Multiplier)
+}
+
+// ToLogUncertainty propagates the uncertainty of a number in base units into a logarithmic unit
+func (u UDerived) ToLogUncertainty(x float64, ux float64) float64 {
+	if ux == 0 {
+		return 0
+	}
+	return math.Abs(u.Log) * ux / (x * math.Ln10)
+}
+
+func (u UDerived) logDefinition() string {
+	reference := strconv.FormatFloat(u.Multiplier, 'g', -1, 64)
+	if !u.UnitExponents.IsNoUnit() {
+		reference += " " + u.UnitExponents.Clone().String()
+	}
+	return strconv.FormatFloat(u.Log, 'g', -1, 64) + " log10(x / " + reference + ")"
+}
@@ -0,0 +1,54 @@
+package quantity
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLogarithmicUnits(t *testing.T) {
+	Convey("Logarithmic units", t, func() {
+		Convey("should convert from and to the linear value", func() {
+			So(UnitDerivedPH.FromLog(7), ShouldAlmostEqual, 1e-7)
+			So(UnitDerivedPH.ToLog(1e-3), ShouldAlmostEqual, 3)
+			So(UnitDerivedDecibel.FromLog(10), ShouldAlmostEqual, 10)
+			So(UnitDerivedDecibel.ToLog(100), ShouldAlmostEqual, 20)
+		})
+		Convey("should propagate uncertainties", func() {
+			So(UnitDerivedPH.ToLogUncertainty(1e-3, 1e-5), ShouldAlmostEqual, 0.01/math.Ln10)
+			So(UnitDerivedPH.FromLogUncertainty(3, 0.01), ShouldAlmostEqual, 1e-3*math.Ln10*0.01)
+		})
+		Convey("should display whole quantities", func() {
+			q := Q{
+				Number:            Float(1e-3),
+				UnitExponents:     UnitDerivedMolar.UnitExponents.Clone(),
+				DerivedUnitsToUse: UDerivedList{UnitDerivedPH},
+			}
+			num, units := q.Format()
+			So(num.Float64(), ShouldAlmostEqual, 3)
+			So(units, ShouldResemble, UnitDisplayList{{Identifier: "pH", Exponent: IntExponent(1)}})
+
+			q.UnitExponents = UCombination{{Unit: UnitMole, Exponent: IntExponent(1)}}
+			num, _ = q.Format()
+			So(num.Float64(), ShouldAlmostEqual, 1e-3)
+		})
+		Convey("should not be prefixed", func() {
+			r := NewDefaultRegistry()
+			So(r.Prefixes("pH"), ShouldBeEmpty)
+			So(UnitDerivedPH.Definition(), ShouldEqual, "-1 log10(x / 1 (mol)(l)-1)")
+		})
+		Convey("should be defined in definition files", func() {
+			r := NewDefaultRegistry()
+			So(r.LoadDefinitions(strings.NewReader(`{"derived": [{"identifier": "pCa", "log": -1, "units": {"mM": 1}}]}`)), ShouldBeNil)
+			_, pCa := r.LookupExact("pCa")
+			So(pCa.Log, ShouldEqual, -1)
+			So(pCa.Multiplier, ShouldAlmostEqual, 1e-3)
+
+			err := r.LoadDefinitions(strings.NewReader(`{"derived": [{"identifier": "x", "units": {"pH": 1}}]}`))
+			So(errors.As(err, new(ErrLogarithmicInDefinition)), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package quantity
+
+import "math"
+
+// ConvertMolar converts between mass and amount of substance with the molar mass mw, in (g)(mol)-1.
+//
+// The grams in q are replaced by moles if q is in grams, like a mass concentration in (g)(l)-1 into (M),
+// or the moles in q are replaced by grams if q is in moles, like an amount in (mol) into (g).
+// Preferred derived units made of the replaced unit are dropped, and (M) is preferred for molar concentrations
+// over any other preferred unit.
+//
+// ok is false if mw is not a molar mass, or q is in both or neither of grams and moles.
+func ConvertMolar(q Q, mw Q) (res Q, ok bool) {
+	if !mw.UnitExponents.Equal(&UnitDerivedAmu.UnitExponents) {
+		return q, false
+	}
+	gram, mole := q.UnitExponents.ExponentOf(UnitGram), q.UnitExponents.ExponentOf(UnitMole)
+
+	var from, to U
+	var exp, mwExp Exponent
+	switch {
+	case !gram.IsZero() && mole.IsZero():
+		// 1 (g) = 1/mw (mol)
+		from, to, exp, mwExp = UnitGram, UnitMole, gram, gram.Neg()
+	case gram.IsZero() && !mole.IsZero():
+		// 1 (mol) = mw (g)
+		from, to, exp, mwExp = UnitMole, UnitGram, mole, mole
+	default:
+		return q, false
+	}
+
+	res = Q{
+		Number:        q.Value().Mul(PowNumber(mw.Value(), mwExp)),
+		UnitExponents: q.UnitExponents.Clone(),
+		Scale:         q.Scale,
+	}
+	for i := range res.UnitExponents {
+		if res.UnitExponents[i].Unit == from {
+			res.UnitExponents[i].Unit = to
+		}
+	}
+	res.UnitExponents.Simplify()
+	if q.Uncertainty != 0 || mw.Uncertainty != 0 {
+		// relative uncertainties add in quadrature, the one of mw scaled by its exponent
+		res.Uncertainty = math.Abs(res.Number.Float64()) * math.Hypot(
+			q.Uncertainty/q.Value().Float64(),
+			exp.Float64()*mw.Uncertainty/mw.Value().Float64(),
+		)
+	}
+
+	molar := res.UnitExponents.Equal(&UnitDerivedMolar.UnitExponents)
+	for _, d := range q.DerivedUnitsToUse {
+		if d.UnitExponents.ExponentOf(from).IsZero() && !(molar && d.UnitExponents.HasOverlap(UnitDerivedMolar.UnitExponents)) {
+			res.DerivedUnitsToUse = append(res.DerivedUnitsToUse, d)
+		}
+	}
+	if molar {
+		res.DerivedUnitsToUse = append(res.DerivedUnitsToUse, UnitDerivedMolar)
+	}
+	return res, true
+}
@@ -0,0 +1,50 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConvertMolar(t *testing.T) {
+	Convey("Mass and molar conversions", t, func() {
+		mw := Q{Number: Float(58.44), Uncertainty: 0.5844, UnitExponents: UnitDerivedAmu.UnitExponents.Clone()}
+		gPerL := UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}, {Unit: UnitLiter, Exponent: IntExponent(-1)}}
+
+		Convey("should convert mass concentrations into molar concentrations", func() {
+			res, ok := ConvertMolar(Q{Number: Float(5.844), UnitExponents: gPerL}, mw)
+			So(ok, ShouldBeTrue)
+			So(res.Number.Float64(), ShouldAlmostEqual, 0.1)
+			So(res.UnitExponents.Equal(&UnitDerivedMolar.UnitExponents), ShouldBeTrue)
+			So(res.DerivedUnitsToUse, ShouldResemble, UDerivedList{UnitDerivedMolar})
+			// only the molar mass is uncertain, by 1%
+			So(res.Uncertainty, ShouldAlmostEqual, 0.001)
+		})
+		Convey("should treat the zero value as zero", func() {
+			res, ok := ConvertMolar(Q{UnitExponents: UCombination{{Unit: UnitMole, Exponent: IntExponent(1)}}}, mw)
+			So(ok, ShouldBeTrue)
+			So(res.Number.Float64(), ShouldEqual, 0)
+		})
+		Convey("should convert amounts into mass", func() {
+			res, ok := ConvertMolar(Q{Number: Float(2), UnitExponents: UCombination{{Unit: UnitMole, Exponent: IntExponent(1)}}}, mw)
+			So(ok, ShouldBeTrue)
+			So(res.Number.Float64(), ShouldAlmostEqual, 116.88)
+			So(res.UnitExponents, ShouldResemble, UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}})
+		})
+		Convey("should drop preferred units of the replaced unit", func() {
+			q := Q{Number: Float(2), UnitExponents: UnitDerivedMolar.UnitExponents.Clone(), DerivedUnitsToUse: UDerivedList{UnitDerivedMolar}}
+			res, ok := ConvertMolar(q, mw)
+			So(ok, ShouldBeTrue)
+			So(res.UnitExponents.Equal(&gPerL), ShouldBeTrue)
+			So(res.DerivedUnitsToUse, ShouldBeEmpty)
+		})
+		Convey("should refuse ambiguous quantities", func() {
+			_, ok := ConvertMolar(mw, mw)
+			So(ok, ShouldBeFalse)
+			_, ok = ConvertMolar(Q{Number: Float(1), UnitExponents: UCombination{{Unit: UnitLiter, Exponent: IntExponent(1)}}}, mw)
+			So(ok, ShouldBeFalse)
+			_, ok = ConvertMolar(Q{Number: Float(1), UnitExponents: gPerL}, Q{Number: Float(1), UnitExponents: gPerL})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
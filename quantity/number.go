@@ -0,0 +1,185 @@
+package quantity
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Number is the magnitude of a quantity.
+//
+// Operands of different representations are converted into the wider representation
+// before the operation, so results never lose precision of either operand.
+type Number interface {
+	Add(n Number) Number
+	Sub(n Number) Number
+	Mul(n Number) Number
+	Quo(n Number) Number
+	Neg() Number
+	// Sqrt returns the square root, NaN if the number is negative
+	Sqrt() Number
+	// Sign returns -1, 0 or 1 depending on the sign of the number
+	Sign() int
+	Float64() float64
+	String() string
+
+	// rank orders the representations from the narrowest to the widest
+	rank() int
+	// convert converts n into the same representation
+	convert(n Number) Number
+}
+
+// NumberMode selects the representation of numbers in a session
+type NumberMode int
+
+const (
+	// NumberModeFloat64 uses float64 numbers
+	NumberModeFloat64 NumberMode = iota
+	// NumberModeBigFloat uses arbitrary precision big.Float numbers
+	NumberModeBigFloat
+	// NumberModeRational uses exact big.Rat numbers, as long as the operations allow
+	NumberModeRational
+)
+
+// DefaultBigFloatPrecision is the mantissa precision in bits used in NumberModeBigFloat
+// when no precision is specified
+const DefaultBigFloatPrecision = 256
+
+// NumberContext creates numbers in the representation chosen for a session.
+//
+// The zero value creates float64 numbers.
+type NumberContext struct {
+	Mode NumberMode
+
+	// Precision is the mantissa precision in bits for NumberModeBigFloat,
+	// DefaultBigFloatPrecision is used if this is zero
+	Precision uint
+}
+
+// FloatPrecision returns the mantissa precision in bits of the numbers created by c
+func (c NumberContext) FloatPrecision() uint {
+	switch c.Mode {
+	case NumberModeBigFloat:
+		if c.Precision == 0 {
+			return DefaultBigFloatPrecision
+		}
+		return c.Precision
+	}
+	return 53
+}
+
+// FromFloat64 creates a number from f
+func (c NumberContext) FromFloat64(f float64) Number {
+	return c.Convert(Float(f))
+}
+
+// FromBigFloat creates a number from f
+func (c NumberContext) FromBigFloat(f *big.Float) Number {
+	switch c.Mode {
+	case NumberModeBigFloat:
+		return NewBigFloat(new(big.Float).SetPrec(c.FloatPrecision()).Set(f))
+	case NumberModeRational:
+		return c.Convert(NewBigFloat(f))
+	}
+	ret, _ := f.Float64()
+	return Float(ret)
+}
+
+// FromRat creates a number from r
+func (c NumberContext) FromRat(r *big.Rat) Number {
+	return c.Convert(NewRat(new(big.Rat).Set(r)))
+}
+
+// Convert converts n into the representation of c
+func (c NumberContext) Convert(n Number) Number {
+	switch c.Mode {
+	case NumberModeBigFloat:
+		return NewBigFloat(new(big.Float).SetPrec(c.FloatPrecision())).convert(n)
+	case NumberModeRational:
+		return NewRat(new(big.Rat)).convert(n)
+	}
+	return Float(n.Float64())
+}
+
+// widen converts x into the representation of n, if n is of a wider representation
+func widen(x Number, n Number) (Number, bool) {
+	if n.rank() > x.rank() {
+		if w := n.convert(x); w.rank() == n.rank() {
+			return w, true
+		}
+	}
+	return nil, false
+}
+
+// PowNumber raises n to the power of e.
+//
+// Integer powers are computed in the representation of n,
+// fractional powers are computed in float64.
+func PowNumber(n Number, e Exponent) Number {
+	if !e.IsInteger() {
+		return n.convert(Float(math.Pow(n.Float64(), e.Float64())))
+	}
+	res := n.convert(Float(1))
+	for i := 0; i < e.Num(); i++ {
+		res = res.Mul(n)
+	}
+	for i := 0; i > e.Num(); i-- {
+		res = res.Quo(n)
+	}
+	return res
+}
+
+// decimalRat returns the shortest decimal representation of f as an exact rational number,
+// like 1/1000 for 1e-3, or nil if f is NaN or infinite
+func decimalRat(f float64) *big.Rat {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	res, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return res
+}
+
+// ratPow raises r to the integer power n
+func ratPow(r *big.Rat, n int) *big.Rat {
+	res := big.NewRat(1, 1)
+	for i := 0; i < n; i++ {
+		res.Mul(res, r)
+	}
+	for i := 0; i > n; i-- {
+		res.Quo(res, r)
+	}
+	return res
+}
+
+// convertExact computes op on x as an exact rational number, and rounds the result
+// into the representation of x once.
+//
+// Float numbers are taken as their shortest decimal representation like in Rat,
+// so that converting units does not add to the rounding errors of float64.
+// It returns false if x is NaN or infinite.
+func convertExact(x Number, op func(r *big.Rat) *big.Rat) (Number, bool) {
+	r, ok := Rat{}.convert(x).(Rat)
+	if !ok {
+		return nil, false
+	}
+	return x.convert(Rat{r: op(r.Rat())}), true
+}
+
+// mulRat returns the product of x and y, nil if either is nil
+func mulRat(x *big.Rat, y *big.Rat) *big.Rat {
+	if x == nil || y == nil {
+		return nil
+	}
+	return new(big.Rat).Mul(x, y)
+}
+
+// mulExact multiplies x by factor with convertExact, or in float64 if x is NaN or infinite
+func mulExact(x Number, factor *big.Rat) Number {
+	if res, ok := convertExact(x, func(r *big.Rat) *big.Rat {
+		return r.Mul(r, factor)
+	}); ok {
+		return res
+	}
+	f, _ := factor.Float64()
+	return x.Mul(Float(f))
+}
@@ -0,0 +1,133 @@
+package quantity
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// BigFloat is an arbitrary precision floating point number.
+//
+// Operations that would result in NaN result in a Float NaN instead,
+// since big.Float can not represent NaN.
+type BigFloat struct {
+	f *big.Float
+}
+
+// NewBigFloat creates a BigFloat from f, the result takes ownership of f
+func NewBigFloat(f *big.Float) BigFloat {
+	return BigFloat{f: f}
+}
+
+// BigFloat returns a copy of the underlying big.Float
+func (b BigFloat) BigFloat() *big.Float {
+	return new(big.Float).Copy(b.f)
+}
+
+func (b BigFloat) binary(n Number, op func(z *big.Float, x *big.Float, y *big.Float) *big.Float) (res Number) {
+	y, ok := b.convert(n).(BigFloat)
+	if !ok {
+		return Float(math.NaN())
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(big.ErrNaN); !ok {
+				panic(r)
+			}
+			res = Float(math.NaN())
+		}
+	}()
+	prec := b.f.Prec()
+	if y.f.Prec() > prec {
+		prec = y.f.Prec()
+	}
+	return BigFloat{f: op(new(big.Float).SetPrec(prec), b.f, y.f)}
+}
+
+func (b BigFloat) Add(n Number) Number {
+	if w, ok := widen(b, n); ok {
+		return w.Add(n)
+	}
+	return b.binary(n, (*big.Float).Add)
+}
+
+func (b BigFloat) Sub(n Number) Number {
+	if w, ok := widen(b, n); ok {
+		return w.Sub(n)
+	}
+	return b.binary(n, (*big.Float).Sub)
+}
+
+func (b BigFloat) Mul(n Number) Number {
+	if w, ok := widen(b, n); ok {
+		return w.Mul(n)
+	}
+	return b.binary(n, (*big.Float).Mul)
+}
+
+func (b BigFloat) Quo(n Number) Number {
+	if w, ok := widen(b, n); ok {
+		return w.Quo(n)
+	}
+	return b.binary(n, (*big.Float).Quo)
+}
+
+func (b BigFloat) Neg() Number {
+	return BigFloat{f: new(big.Float).Neg(b.f)}
+}
+
+func (b BigFloat) Sqrt() Number {
+	if b.f.Sign() < 0 {
+		return Float(math.NaN())
+	}
+	return BigFloat{f: new(big.Float).SetPrec(b.f.Prec()).Sqrt(b.f)}
+}
+
+func (b BigFloat) Sign() int {
+	return b.f.Sign()
+}
+
+func (b BigFloat) Float64() float64 {
+	ret, _ := b.f.Float64()
+	return ret
+}
+
+// String formats the number with all decimal digits that are significant at its precision,
+// except for a few guard digits to hide accumulated rounding errors
+func (b BigFloat) String() string {
+	digits := int(float64(b.f.Prec())*math.Log10(2)) - 3
+	if digits < 1 {
+		digits = 1
+	}
+	return b.f.Text('g', digits)
+}
+
+func (b BigFloat) rank() int {
+	return 2
+}
+
+// convert converts n into a BigFloat of the same precision as b, rounding Rat numbers once.
+//
+// Float numbers are converted from their shortest decimal representation,
+// so unit multipliers like 1e-3 are exact to the full precision.
+func (b BigFloat) convert(n Number) Number {
+	switch n := n.(type) {
+	case BigFloat:
+		return n
+	case Rat:
+		return BigFloat{f: new(big.Float).SetPrec(b.f.Prec()).SetRat(n.r)}
+	case Float:
+		f := float64(n)
+		if math.IsNaN(f) {
+			return n
+		} else if math.IsInf(f, 0) {
+			return BigFloat{f: new(big.Float).SetPrec(b.f.Prec()).SetInf(f < 0)}
+		}
+		res, _, err := big.ParseFloat(strconv.FormatFloat(f, 'g', -1, 64), 10, b.f.Prec(), big.ToNearestEven)
+		if err != nil {
+			return BigFloat{f: new(big.Float).SetPrec(b.f.Prec()).SetFloat64(f)}
+		}
+		return BigFloat{f: res}
+	}
+	return b.convert(Float(n.Float64()))
+}
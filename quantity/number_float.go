@@ -0,0 +1,72 @@
+package quantity
+
+import (
+	"math"
+	"strconv"
+)
+
+// Float is a float64 number
+type Float float64
+
+func (f Float) Add(n Number) Number {
+	if w, ok := widen(f, n); ok {
+		return w.Add(n)
+	}
+	return f + Float(n.Float64())
+}
+
+func (f Float) Sub(n Number) Number {
+	if w, ok := widen(f, n); ok {
+		return w.Sub(n)
+	}
+	return f - Float(n.Float64())
+}
+
+func (f Float) Mul(n Number) Number {
+	if w, ok := widen(f, n); ok {
+		return w.Mul(n)
+	}
+	return f * Float(n.Float64())
+}
+
+func (f Float) Quo(n Number) Number {
+	if w, ok := widen(f, n); ok {
+		return w.Quo(n)
+	}
+	return f / Float(n.Float64())
+}
+
+func (f Float) Neg() Number {
+	return -f
+}
+
+func (f Float) Sqrt() Number {
+	return Float(math.Sqrt(float64(f)))
+}
+
+func (f Float) Sign() int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
+
+func (f Float) Float64() float64 {
+	return float64(f)
+}
+
+// String formats the number with the fewest digits that parse back into the same float64
+func (f Float) String() string {
+	return strconv.FormatFloat(float64(f), 'g', -1, 64)
+}
+
+func (f Float) rank() int {
+	return 0
+}
+
+func (f Float) convert(n Number) Number {
+	return Float(n.Float64())
+}
@@ -0,0 +1,192 @@
+package quantity
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Rat is an exact rational number.
+//
+// Operations that can not be carried out exactly, like square roots of
+// non-square numbers, fall back to BigFloat, and division by zero falls back to Float.
+type Rat struct {
+	r *big.Rat
+}
+
+// NewRat creates a Rat from r, the result takes ownership of r
+func NewRat(r *big.Rat) Rat {
+	return Rat{r: r}
+}
+
+// Rat returns a copy of the underlying big.Rat
+func (r Rat) Rat() *big.Rat {
+	return new(big.Rat).Set(r.r)
+}
+
+func (r Rat) binary(n Number, op func(z *big.Rat, x *big.Rat, y *big.Rat) *big.Rat) Number {
+	y, ok := r.convert(n).(Rat)
+	if !ok {
+		return nil
+	}
+	return Rat{r: op(new(big.Rat), r.r, y.r)}
+}
+
+func (r Rat) Add(n Number) Number {
+	if w, ok := widen(r, n); ok {
+		return w.Add(n)
+	}
+	if res := r.binary(n, (*big.Rat).Add); res != nil {
+		return res
+	}
+	return Float(r.Float64()) + Float(n.Float64())
+}
+
+func (r Rat) Sub(n Number) Number {
+	if w, ok := widen(r, n); ok {
+		return w.Sub(n)
+	}
+	if res := r.binary(n, (*big.Rat).Sub); res != nil {
+		return res
+	}
+	return Float(r.Float64()) - Float(n.Float64())
+}
+
+func (r Rat) Mul(n Number) Number {
+	if w, ok := widen(r, n); ok {
+		return w.Mul(n)
+	}
+	if res := r.binary(n, (*big.Rat).Mul); res != nil {
+		return res
+	}
+	return Float(r.Float64()) * Float(n.Float64())
+}
+
+func (r Rat) Quo(n Number) Number {
+	if w, ok := widen(r, n); ok {
+		return w.Quo(n)
+	}
+	if n.Sign() != 0 {
+		if res := r.binary(n, (*big.Rat).Quo); res != nil {
+			return res
+		}
+	}
+	return Float(r.Float64()) / Float(n.Float64())
+}
+
+func (r Rat) Neg() Number {
+	return Rat{r: new(big.Rat).Neg(r.r)}
+}
+
+// Sqrt returns the exact square root if both the numerator and the denominator
+// are perfect squares, otherwise a BigFloat of DefaultBigFloatPrecision
+func (r Rat) Sqrt() Number {
+	if r.r.Sign() < 0 {
+		return Float(math.NaN())
+	}
+	num := new(big.Int).Sqrt(r.r.Num())
+	den := new(big.Int).Sqrt(r.r.Denom())
+	if new(big.Int).Mul(num, num).Cmp(r.r.Num()) == 0 &&
+		new(big.Int).Mul(den, den).Cmp(r.r.Denom()) == 0 {
+		return Rat{r: new(big.Rat).SetFrac(num, den)}
+	}
+	f := new(big.Float).SetPrec(DefaultBigFloatPrecision).SetRat(r.r)
+	return BigFloat{f: f.Sqrt(f)}
+}
+
+func (r Rat) Sign() int {
+	return r.r.Sign()
+}
+
+func (r Rat) Float64() float64 {
+	ret, _ := r.r.Float64()
+	return ret
+}
+
+// DefaultRatDigits is the number of significant digits used to display rational numbers
+// without a finite decimal representation
+const DefaultRatDigits = 20
+
+// String formats the number in decimal, which is exact if the number has a finite
+// decimal representation, and rounded to DefaultRatDigits significant digits otherwise.
+func (r Rat) String() string {
+	den := new(big.Int).Set(r.r.Denom())
+	var twos, fives int
+	two, five, rem := big.NewInt(2), big.NewInt(5), new(big.Int)
+	for den.QuoRem(den, two, rem); rem.Sign() == 0; den.QuoRem(den, two, rem) {
+		twos++
+	}
+	den.Mul(den, two).Add(den, rem)
+	for den.QuoRem(den, five, rem); rem.Sign() == 0; den.QuoRem(den, five, rem) {
+		fives++
+	}
+	den.Mul(den, five).Add(den, rem)
+	if den.Cmp(big.NewInt(1)) == 0 {
+		if fives > twos {
+			twos = fives
+		}
+		return r.r.FloatString(twos)
+	}
+	return new(big.Float).SetPrec(DefaultBigFloatPrecision).SetRat(r.r).Text('g', DefaultRatDigits)
+}
+
+// FractionString formats the number as a fraction, like 1/3
+func (r Rat) FractionString() string {
+	return r.r.RatString()
+}
+
+func (r Rat) rank() int {
+	return 1
+}
+
+// convert converts n into a Rat.
+//
+// Float numbers are converted from their shortest decimal representation,
+// so unit multipliers like 1e-3 are exact. NaN and infinities stay a Float.
+func (r Rat) convert(n Number) Number {
+	switch n := n.(type) {
+	case Rat:
+		return n
+	case Float:
+		f := float64(n)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return n
+		}
+		res, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+		if !ok {
+			return Rat{r: new(big.Rat).SetFloat64(f)}
+		}
+		return Rat{r: res}
+	case BigFloat:
+		if n.f.IsInf() {
+			return Float(n.Float64())
+		}
+		res, _ := n.f.Rat(nil)
+		return Rat{r: res}
+	}
+	return r.convert(Float(n.Float64()))
+}
+
+// ToRat returns n as a rational number, float64 numbers as their shortest decimal representation.
+// It returns nil if n is NaN or infinite.
+func ToRat(n Number) *big.Rat {
+	r, ok := Rat{}.convert(n).(Rat)
+	if !ok {
+		return nil
+	}
+	return r.Rat()
+}
+
+// IsExact tells whether n is an exact number
+func IsExact(n Number) bool {
+	_, ok := n.(Rat)
+	return ok
+}
+
+// FormatNumber formats n for display, as a fraction if fraction is true and n is exact
+func FormatNumber(n Number, fraction bool) string {
+	if r, ok := n.(Rat); ok && fraction {
+		return r.FractionString()
+	}
+	return n.String()
+}
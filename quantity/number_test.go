@@ -0,0 +1,93 @@
+package quantity
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNumber(t *testing.T) {
+	Convey("Numbers", t, func() {
+		ctx := NumberContext{Mode: NumberModeBigFloat, Precision: 128}
+		parse := func(s string) Number {
+			f, _, err := big.ParseFloat(s, 10, ctx.FloatPrecision(), big.ToNearestEven)
+			So(err, ShouldBeNil)
+			return ctx.FromBigFloat(f)
+		}
+
+		Convey("big floats should not accumulate float64 errors", func() {
+			sum := parse("0.1").Add(parse("0.2"))
+			So(sum.String(), ShouldEqual, "0.3")
+
+			n := parse("1")
+			for i := 0; i < 30; i++ {
+				n = n.Quo(Float(3))
+			}
+			for i := 0; i < 30; i++ {
+				n = n.Mul(Float(3))
+			}
+			So(n.String(), ShouldEqual, "1")
+		})
+		Convey("unit multipliers should be exact in big floats", func() {
+			So(parse("1").Mul(Float(1e-3)).Quo(Float(1e-6)).String(), ShouldEqual, "1000")
+		})
+		Convey("mixed operands should be promoted", func() {
+			So(Float(0.1).Add(parse("0.2")), ShouldHaveSameTypeAs, BigFloat{})
+			So(Float(0.1).Add(parse("0.2")).String(), ShouldEqual, "0.3")
+		})
+		Convey("NaN should be handled", func() {
+			So(math.IsNaN(parse("-1").Sqrt().Float64()), ShouldBeTrue)
+			So(math.IsNaN(parse("0").Quo(parse("0")).Float64()), ShouldBeTrue)
+			So(math.IsNaN(parse("1").Add(Float(math.NaN())).Float64()), ShouldBeTrue)
+			So(math.IsNaN(Float(math.NaN()).Add(parse("1")).Float64()), ShouldBeTrue)
+		})
+		Convey("powers", func() {
+			So(PowNumber(parse("10"), IntExponent(-2)).String(), ShouldEqual, "0.01")
+			So(PowNumber(Float(4), NewExponent(1, 2)).Float64(), ShouldAlmostEqual, 2)
+		})
+		Convey("the zero value of quantities should be zero", func() {
+			So(Q{}.Value(), ShouldEqual, Float(0))
+			num, _ := Q{}.Format()
+			So(num, ShouldEqual, Float(0))
+		})
+	})
+}
+
+func TestRat(t *testing.T) {
+	Convey("Rational numbers", t, func() {
+		ctx := NumberContext{Mode: NumberModeRational}
+		parse := func(s string) Number {
+			r, ok := new(big.Rat).SetString(s)
+			So(ok, ShouldBeTrue)
+			return ctx.FromRat(r)
+		}
+
+		Convey("should be exact", func() {
+			sum := parse("0.1").Add(parse("0.2"))
+			So(IsExact(sum), ShouldBeTrue)
+			So(sum.String(), ShouldEqual, "0.3")
+			So(FormatNumber(sum, true), ShouldEqual, "3/10")
+
+			n := parse("1")
+			for i := 0; i < 10; i++ {
+				n = n.Quo(parse("3"))
+			}
+			So(FormatNumber(n, true), ShouldEqual, "1/59049")
+			So(n.String(), ShouldEqual, "1.6935087808430286711e-05")
+		})
+		Convey("unit multipliers should be exact", func() {
+			So(FormatNumber(parse("1").Mul(Float(1e-3)), true), ShouldEqual, "1/1000")
+		})
+		Convey("square roots should only be exact for squares", func() {
+			So(IsExact(parse("9/4").Sqrt()), ShouldBeTrue)
+			So(parse("9/4").Sqrt().String(), ShouldEqual, "1.5")
+			So(IsExact(parse("2").Sqrt()), ShouldBeFalse)
+			So(parse("2").Sqrt().Float64(), ShouldAlmostEqual, math.Sqrt2)
+		})
+		Convey("division by zero should fall back to float", func() {
+			So(math.IsInf(parse("1").Quo(parse("0")).Float64(), 1), ShouldBeTrue)
+		})
+	})
+}
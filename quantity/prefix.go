@@ -0,0 +1,137 @@
+package quantity
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Prefix is a decimal prefix that can be applied to a unit
+type Prefix struct {
+	Symbol     string  `json:"symbol"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// SIPrefixSet is the name of the set of SI prefixes in a registry
+const SIPrefixSet = "SI"
+
+// SIPrefixes are all prefixes defined by the SI, from yotta to yocto.
+//
+// Both the micro sign and the greek letter mu are accepted for micro,
+// as well as "u" for convenient ASCII input.
+var SIPrefixes = []Prefix{
+	{Symbol: "Y", Multiplier: 1e24},
+	{Symbol: "Z", Multiplier: 1e21},
+	{Symbol: "E", Multiplier: 1e18},
+	{Symbol: "P", Multiplier: 1e15},
+	{Symbol: "T", Multiplier: 1e12},
+	{Symbol: "G", Multiplier: 1e9},
+	{Symbol: "M", Multiplier: 1e6},
+	{Symbol: "k", Multiplier: 1e3},
+	{Symbol: "h", Multiplier: 1e2},
+	{Symbol: "da", Multiplier: 1e1},
+	{Symbol: "d", Multiplier: 1e-1},
+	{Symbol: "c", Multiplier: 1e-2},
+	{Symbol: "m", Multiplier: 1e-3},
+	{Symbol: "µ", Multiplier: 1e-6},
+	{Symbol: "μ", Multiplier: 1e-6},
+	{Symbol: "u", Multiplier: 1e-6},
+	{Symbol: "n", Multiplier: 1e-9},
+	{Symbol: "p", Multiplier: 1e-12},
+	{Symbol: "f", Multiplier: 1e-15},
+	{Symbol: "a", Multiplier: 1e-18},
+	{Symbol: "z", Multiplier: 1e-21},
+	{Symbol: "y", Multiplier: 1e-24},
+}
+
+// LookupPrefix finds the SI prefix with the given symbol
+func LookupPrefix(symbol string) (Prefix, bool) {
+	for _, p := range SIPrefixes {
+		if p.Symbol == symbol {
+			return p, true
+		}
+	}
+	return Prefix{}, false
+}
+
+// PrefixedIdentifier is one possible way of reading an identifier
+// as a prefix followed by the identifier of a unit.
+type PrefixedIdentifier struct {
+	Prefix Prefix
+	Unit   string
+}
+
+// SplitPrefix returns all possible ways to read identifier as a prefixed unit,
+// longer prefixes first.
+//
+// Example: "dam" can be read as "da" "m" or "d" "am".
+func SplitPrefix(identifier string) (res []PrefixedIdentifier) {
+	return SplitPrefixWith(identifier, SIPrefixes)
+}
+
+// SplitPrefixWith is like SplitPrefix, with the given prefixes instead of the SI prefixes
+func SplitPrefixWith(identifier string, prefixes []Prefix) (res []PrefixedIdentifier) {
+	for _, p := range prefixes {
+		if len(identifier) > len(p.Symbol) && strings.HasPrefix(identifier, p.Symbol) {
+			res = append(res, PrefixedIdentifier{
+				Prefix: p,
+				Unit:   strings.TrimPrefix(identifier, p.Symbol),
+			})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return len(res[i].Prefix.Symbol) > len(res[j].Prefix.Symbol)
+	})
+	return
+}
+
+// Apply derives a new unit by applying the prefix on a base unit.
+func (p Prefix) Apply(base U) UDerived {
+	return UDerived{
+		Identifier:    p.Symbol + base.Identifier,
+		Offset:        0,
+		Multiplier:    p.Multiplier,
+		UnitExponents: UCombination{UExp{Unit: base, Exponent: IntExponent(1)}},
+		Prefix:        p,
+	}
+}
+
+// ApplyDerived derives a new unit by applying the prefix on a derived unit.
+func (p Prefix) ApplyDerived(base UDerived) UDerived {
+	return UDerived{
+		Identifier:    p.Symbol + base.Identifier,
+		Offset:        base.Offset,
+		Multiplier:    base.Multiplier * p.Multiplier,
+		UnitExponents: base.UnitExponents.Clone(),
+		Interval:      base.Interval,
+		ExplicitOnly:  base.ExplicitOnly,
+		Prefix:        p,
+		Log:           base.Log,
+
+		exactMultiplier: mulRat(base.ExactMultiplier(), decimalRat(p.Multiplier)),
+		exactOffset:     base.exactOffset,
+	}
+}
+
+// Unprefixed returns the unit without its prefix, if it was derived by applying a prefix
+func (u UDerived) Unprefixed() UDerived {
+	if u.Prefix.Symbol == "" {
+		return u
+	}
+	u.Identifier = strings.TrimPrefix(u.Identifier, u.Prefix.Symbol)
+	u.Multiplier /= u.Prefix.Multiplier
+	u.exactMultiplier = mulRat(u.ExactMultiplier(), new(big.Rat).Inv(decimalRat(u.Prefix.Multiplier)))
+	u.Prefix = Prefix{}
+	return u
+}
+
+// IsEngineering reports whether the prefix is a power of 1000, or not a power of 10 at all
+// like binary prefixes, which excludes h, da, d and c.
+func (p Prefix) IsEngineering() bool {
+	exp := math.Round(math.Log10(p.Multiplier))
+	if math.Abs(p.Multiplier-math.Pow(10, exp)) > p.Multiplier*1e-9 {
+		return true
+	}
+	return int(exp)%3 == 0
+}
@@ -1,10 +1,15 @@
 package quantity
 
-import "sort"
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
 
 type UnitDisplay struct {
 	Identifier string
-	Exponent   int
+	Exponent   Exponent
 }
 
 type UnitDisplayList []UnitDisplay
@@ -33,7 +38,7 @@ func (u UnitDisplayList) Less(i int, j int) bool {
 	if u[i].Exponent == u[j].Exponent {
 		return u[i].Identifier > u[j].Identifier
 	}
-	return u[i].Exponent > u[j].Exponent
+	return u[j].Exponent.Less(u[i].Exponent)
 }
 
 // Swap swaps the elements with indexes i and j.
@@ -41,37 +46,203 @@ func (u UnitDisplayList) Swap(i int, j int) {
 	u[i], u[j] = u[j], u[i]
 }
 
-func (q Q) Format() (num float64, res UnitDisplayList) {
-	num = q.Number
+// String formats the units as a single unit expression that can be parsed back, like (mg/ml)
+// or (J/(mol*K)), see syntax.ParseUnitExpression. It is empty if there are no units.
+func (u UnitDisplayList) String() string {
+	var num, den []string
+	for _, d := range u {
+		switch d.Exponent.Sign() {
+		case 1:
+			num = append(num, d.factorString(d.Exponent))
+		case -1:
+			den = append(den, d.factorString(d.Exponent.Neg()))
+		}
+	}
+	if len(num) == 0 && len(den) == 0 {
+		return ""
+	}
+	res := "1"
+	if len(num) > 0 {
+		res = strings.Join(num, "*")
+	}
+	switch len(den) {
+	case 0:
+	case 1:
+		res += "/" + den[0]
+	default:
+		res += "/(" + strings.Join(den, "*") + ")"
+	}
+	return "(" + res + ")"
+}
+
+// factorString formats the unit with the exponent exp, like m^2 or Hz^(1/2)
+func (d UnitDisplay) factorString(exp Exponent) string {
+	switch {
+	case exp == IntExponent(1):
+		return d.Identifier
+	case exp.IsInteger():
+		return d.Identifier + "^" + exp.String()
+	}
+	return d.Identifier + "^(" + exp.String() + ")"
+}
+
+// QDisplay is a quantity converted into its units for display
+type QDisplay struct {
+	Number Number
+	// Uncertainty is the standard uncertainty of Number in the display units
+	Uncertainty float64
+	Units       UnitDisplayList
+}
+
+// NumberString formats the number for display, with uncertainty if it has one.
+//
+// If fraction is true, exact numbers without uncertainty are displayed as fractions.
+func (d QDisplay) NumberString(fraction bool) string {
+	if d.Uncertainty != 0 {
+		return FormatUncertain(d.Number.Float64(), d.Uncertainty)
+	}
+	return FormatNumber(d.Number, fraction)
+}
+
+// String formats the quantity as a number followed by a unit expression, like 5 (mg/ml)
+// or 1.500±0.020 (g), which gives the same quantity when entered again.
+//
+// If fraction is true, exact numbers without uncertainty are displayed as fractions, like 1/3.
+func (d QDisplay) String(fraction bool) string {
+	if units := d.Units.String(); units != "" {
+		return d.NumberString(fraction) + " " + units
+	}
+	return d.NumberString(fraction)
+}
+
+// Format converts the quantity into the preferred derived units for display.
+func (q Q) Format() (num Number, res UnitDisplayList) {
+	d := q.Display()
+	return d.Number, d.Units
+}
+
+// FormatOptions controls how quantities are converted into units for display
+type FormatOptions struct {
+	// AutoDerived are derived units that are chosen automatically when they simplify
+	// the display, like Da for (g)(mol)-1, usually all derived units of a Registry.
+	//
+	// They are only applied on what remains after the preferred derived units of the quantity.
+	// Units with an offset, interval units and ExplicitOnly units are never chosen.
+	AutoDerived UDerivedList
+
+	// AutoPrefix changes the prefix of a unit so that the number is in [1, 1000), like 25 (µg)
+	// instead of 0.000025 (g), unless the quantity has ExplicitUnits.
+	//
+	// Only engineering prefixes are used, that is no h, da, d or c.
+	AutoPrefix bool
+	// Prefixes finds the prefixes accepted by the unit with the given identifier for AutoPrefix,
+	// all units accept SI prefixes if nil.
+	Prefixes func(identifier string) []Prefix
+}
+
+// FormatWith is like Format, with derived units chosen as specified in opts
+func (q Q) FormatWith(opts FormatOptions) (num Number, res UnitDisplayList) {
+	d := q.DisplayWith(opts)
+	return d.Number, d.Units
+}
+
+// Display converts the quantity into the preferred derived units for display.
+func (q Q) Display() QDisplay {
+	return q.DisplayWith(FormatOptions{})
+}
+
+// DisplayWith converts the quantity into the preferred derived units for display,
+// and then into derived units chosen as specified in opts.
+//
+// Logarithmic units only apply when the whole quantity is expressed in them, like pH.
+//
+// Offsets are only applied when the whole quantity is expressed in a single unit with an offset,
+// like 20 °C. Absolute values are converted with the offset, differences are displayed with the
+// corresponding interval unit (like Δ°C), and offset units within compound units
+// (like °C/min) are always treated as differences.
+func (q Q) DisplayWith(opts FormatOptions) (res QDisplay) {
+	num := q.Value()
+	uncertainty := q.Uncertainty
 	comb := q.UnitExponents
 
+	var fits []derivedPower
+	// scaleApplied is set when the quantity is displayed in a unit with an offset or a logarithmic unit
+	scaleApplied := false
 	for _, d := range q.DerivedUnitsToUse {
-		remain, exp := d.UnitExponents.Derive(comb)
-		if exp != 0 {
-			comb = remain
-
-			for i := 0; i < exp; i++ {
-				num /= d.Multiplier
-				num -= d.Offset
+		if d.IsLogarithmic() {
+			// logarithmic units only apply to the whole of a positive quantity
+			if x := num.Float64(); !scaleApplied && x > 0 && q.UnitExponents.Equal(&d.UnitExponents) {
+				num = num.convert(Float(d.ToLog(x)))
+				uncertainty = d.ToLogUncertainty(x, uncertainty)
+				scaleApplied = true
+				comb = nil
+				res.Units = append(res.Units, UnitDisplay{
+					Identifier: d.Identifier,
+					Exponent:   IntExponent(1),
+				})
 			}
-			for i := 0; i > exp; i-- {
+			continue
+		}
+		if d.UnitExponents.IsNoUnit() {
+			// dimensionless units like (%) only apply to dimensionless quantities
+			if q.UnitExponents.IsNoUnit() && !scaleApplied {
+				fits = append(fits, derivedPower{unit: d, exp: IntExponent(1), preferred: true})
+			}
+			continue
+		}
+		remain, exp := d.UnitExponents.Derive(comb)
+		if exp.IsZero() {
+			continue
+		}
+		comb = remain
 
-				num += d.Offset
-				num *= d.Multiplier
+		if d.Offset != 0 && exp == IntExponent(1) && q.UnitExponents.Equal(&d.UnitExponents) {
+			identifier := d.Identifier
+			if q.Scale == ScaleInterval {
+				identifier = d.IntervalUnit().Identifier
+				num = d.IntervalUnit().FromBase(num)
+			} else {
+				num = d.FromBase(num)
 			}
-			res = append(res, UnitDisplay{
-				Identifier: d.Identifier,
+			uncertainty /= d.Multiplier
+			scaleApplied = true
+			res.Units = append(res.Units, UnitDisplay{
+				Identifier: identifier,
 				Exponent:   exp,
 			})
+			continue
 		}
+		fits = append(fits, derivedPower{unit: d, exp: exp, preferred: true})
+	}
+	if len(opts.AutoDerived) > 0 && !scaleApplied {
+		fits, comb = autoFit(q.UnitExponents, fits, comb, q.DerivedUnitsToUse, opts.AutoDerived)
 	}
 	comb.Simplify()
 	for _, u := range comb {
-		res = append(res, UnitDisplay{
-			Identifier: u.Unit.Identifier,
-			Exponent:   u.Exponent,
+		fits = append(fits, derivedPower{unit: NewUDerived(u.Unit.Identifier, 1, UCombination{{Unit: u.Unit, Exponent: IntExponent(1)}}), exp: u.Exponent})
+	}
+	// the number is divided by the product of the exact multipliers, to round only once
+	factor := big.NewRat(1, 1)
+	for _, fit := range fits {
+		if m := fit.unit.ExactMultiplier(); m != nil && m.Sign() > 0 && fit.exp.IsInteger() {
+			factor.Mul(factor, ratPow(m, -fit.exp.Num()))
+		} else {
+			num = num.Quo(PowNumber(num.convert(Float(fit.unit.Multiplier)), fit.exp))
+		}
+		uncertainty /= math.Pow(fit.unit.Multiplier, fit.exp.Float64())
+	}
+	num = mulExact(num, factor)
+	if opts.AutoPrefix && !q.ExplicitUnits && !scaleApplied {
+		num, uncertainty = autoPrefix(num, uncertainty, fits, opts.Prefixes)
+	}
+	for _, fit := range fits {
+		res.Units = append(res.Units, UnitDisplay{
+			Identifier: fit.unit.Identifier,
+			Exponent:   fit.exp,
 		})
 	}
-	sort.Sort(res)
+	sort.Sort(res.Units)
+	res.Number = num
+	res.Uncertainty = uncertainty
 	return
 }
@@ -0,0 +1,42 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDisplayString(t *testing.T) {
+	Convey("Display strings", t, func() {
+		Convey("units should be formatted as a unit expression", func() {
+			cases := []struct {
+				Units  UnitDisplayList
+				Expect string
+			}{
+				{nil, ""},
+				{UnitDisplayList{{"mg", IntExponent(1)}}, "(mg)"},
+				{UnitDisplayList{{"mg", IntExponent(1)}, {"ml", IntExponent(-1)}}, "(mg/ml)"},
+				{UnitDisplayList{{"m", IntExponent(2)}}, "(m^2)"},
+				{UnitDisplayList{{"min", IntExponent(-1)}}, "(1/min)"},
+				{UnitDisplayList{{"J", IntExponent(1)}, {"mol", IntExponent(-1)}, {"K", IntExponent(-1)}}, "(J/(mol*K))"},
+				{UnitDisplayList{{"m", IntExponent(1)}, {"kg", IntExponent(1)}, {"s", IntExponent(-2)}}, "(m*kg/s^2)"},
+				{UnitDisplayList{{"Hz", NewExponent(1, 2)}}, "(Hz^(1/2))"},
+				{UnitDisplayList{{"Hz", NewExponent(-1, 2)}}, "(1/Hz^(1/2))"},
+			}
+			for _, c := range cases {
+				So(c.Units.String(), ShouldEqual, c.Expect)
+			}
+		})
+		Convey("quantities should be formatted as a number and a unit expression", func() {
+			d := QDisplay{Number: Float(0.5), Units: UnitDisplayList{{"mg", IntExponent(1)}, {"ml", IntExponent(-1)}}}
+			So(d.String(false), ShouldEqual, "0.5 (mg/ml)")
+			d.Uncertainty = 0.02
+			So(d.String(false), ShouldEqual, "0.500±0.020 (mg/ml)")
+			So(QDisplay{Number: Float(1e-9)}.String(false), ShouldEqual, "1e-09")
+		})
+		Convey("the zero value of quantities should be zero", func() {
+			So(Q{}.Display().String(false), ShouldEqual, "0")
+			So(Q{}.Value(), ShouldEqual, Float(0))
+		})
+	})
+}
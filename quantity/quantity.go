@@ -1,10 +1,43 @@
 package quantity
 
 type Q struct {
-	Number        float64
+	// Number is the magnitude of the quantity, it is nil in the zero value Q{} which is treated as Float(0),
+	// see Value
+	Number        Number
 	UnitExponents UCombination
 
+	// Uncertainty is the standard uncertainty of Number, zero if the quantity is exact
+	Uncertainty float64
+
+	// ExplicitUnits is set when a quantity with units was converted with a unit token, like 5 (mg) (ug),
+	// so they are displayed as is. Numbers given their units like 0.000025 (g)
+	// and results of arithmetic do not have explicit units.
+	ExplicitUnits bool
+
+	// Bare is set for numbers entered without any unit. Dimensionless units like (%) scale bare numbers,
+	// like 5 (%) for 0.05, but only change how other dimensionless quantities are displayed.
+	Bare bool
+
+	// Scale tracks whether a quantity with an offset unit
+	// is an absolute value or a difference
+	Scale Scale
+
 	// this is for display only, track
 	// whether a value is input derived
 	DerivedUnitsToUse UDerivedList
 }
+
+// Value returns the Number of the quantity, or Float(0) if it is nil like in the zero value Q{}
+func (q Q) Value() Number {
+	if q.Number == nil {
+		return Float(0)
+	}
+	return q.Number
+}
+
+// Clone returns a deep copy of the quantity
+func (q Q) Clone() Q {
+	q.UnitExponents = q.UnitExponents.Clone()
+	q.DerivedUnitsToUse = q.DerivedUnitsToUse.Clone()
+	return q
+}
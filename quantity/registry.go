@@ -0,0 +1,386 @@
+package quantity
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Alias is an alternative identifier of a registered unit
+type Alias struct {
+	Alias      string
+	Identifier string
+}
+
+// registryEntry points to a unit in the registry, either a base unit or a derived unit,
+// with the prefixes that can be applied on it
+type registryEntry struct {
+	base     int
+	derived  int
+	prefixes []Prefix
+}
+
+// Registry holds the base units, derived units and aliases known to a session,
+// indexed by identifier.
+//
+// Identifiers, including aliases, must be unique across base and derived units,
+// and base units must have unique IDs.
+//
+// Each unit accepts a set of prefixes, SI prefixes unless specified otherwise.
+// Named prefix sets can be registered for use in definition files, the SI prefixes
+// are always available as SIPrefixSet.
+//
+// The zero value is an empty registry ready for use.
+type Registry struct {
+	units        []U
+	derivedUnits UDerivedList
+	aliases      map[string]string
+	prefixSets   map[string][]Prefix
+	// substances maps the IDs of substance IU units to their substance
+	substances map[int]Substance
+	// unitSets are the names of the builtin unit sets that were loaded
+	unitSets map[string]bool
+
+	byIdentifier map[string]registryEntry
+	byID         map[int]int
+	// allPrefixes is the union of the prefixes accepted by any unit
+	allPrefixes []Prefix
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewDefaultRegistry creates a registry with all builtin units, substances and aliases
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, u := range BuiltinUnits {
+		r.MustAddUnit(u)
+	}
+	for _, s := range BuiltinSubstances {
+		r.MustAddSubstance(s)
+	}
+	for _, d := range BuiltinDerivedUnits {
+		r.MustAddDerivedUnit(d)
+	}
+	for _, d := range UnitDerivedTime {
+		r.MustAddDerivedUnitWithPrefixes(d, nil)
+	}
+	for _, d := range UnitDerivedRatio {
+		r.MustAddDerivedUnitWithPrefixes(d, nil)
+	}
+	for _, a := range BuiltinAliases {
+		r.MustAddAlias(a.Alias, a.Identifier)
+	}
+	return r
+}
+
+func (r *Registry) init() {
+	if r.byIdentifier == nil {
+		r.byIdentifier = make(map[string]registryEntry)
+		r.byID = make(map[int]int)
+		r.aliases = make(map[string]string)
+		r.prefixSets = map[string][]Prefix{SIPrefixSet: SIPrefixes}
+		r.substances = make(map[int]Substance)
+		r.unitSets = make(map[string]bool)
+	}
+}
+
+func (r *Registry) addPrefixes(prefixes []Prefix) {
+	for _, p := range prefixes {
+		if !hasPrefix(r.allPrefixes, p) {
+			r.allPrefixes = append(r.allPrefixes, p)
+		}
+	}
+}
+
+func hasPrefix(prefixes []Prefix, p Prefix) bool {
+	for _, p2 := range prefixes {
+		if p2 == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Registry) checkIdentifier(identifier string) error {
+	if identifier == "" || identifier == "1" || strings.IndexFunc(identifier, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '(' || r == ')'
+	}) >= 0 {
+		return ErrInvalidUnitIdentifier{Identifier: identifier}
+	}
+	if _, ok := r.byIdentifier[identifier]; ok {
+		return ErrDuplicateUnit{Identifier: identifier}
+	}
+	return nil
+}
+
+// AddUnit registers a base unit accepting SI prefixes
+func (r *Registry) AddUnit(u U) error {
+	return r.AddUnitWithPrefixes(u, SIPrefixes)
+}
+
+// AddUnitWithPrefixes registers a base unit accepting the given prefixes
+func (r *Registry) AddUnitWithPrefixes(u U, prefixes []Prefix) error {
+	r.init()
+	if err := r.checkIdentifier(u.Identifier); err != nil {
+		return err
+	}
+	if idx, ok := r.byID[u.ID]; ok {
+		return ErrUnitIDConflict{ID: u.ID, Identifier: u.Identifier, Existing: r.units[idx].Identifier}
+	}
+	r.units = append(r.units, u)
+	r.byID[u.ID] = len(r.units) - 1
+	r.byIdentifier[u.Identifier] = registryEntry{base: len(r.units) - 1, derived: -1, prefixes: prefixes}
+	r.addPrefixes(prefixes)
+	return nil
+}
+
+// AddDerivedUnit registers a derived unit accepting SI prefixes,
+// all base units it is made of must be registered.
+//
+// Prefixes are never applied on units with an offset or logarithmic units.
+func (r *Registry) AddDerivedUnit(d UDerived) error {
+	return r.AddDerivedUnitWithPrefixes(d, SIPrefixes)
+}
+
+// AddDerivedUnitWithPrefixes registers a derived unit accepting the given prefixes
+func (r *Registry) AddDerivedUnitWithPrefixes(d UDerived, prefixes []Prefix) error {
+	r.init()
+	if err := r.checkIdentifier(d.Identifier); err != nil {
+		return err
+	}
+	for _, ue := range d.UnitExponents {
+		if idx, ok := r.byID[ue.Unit.ID]; !ok || r.units[idx] != ue.Unit {
+			return ErrUnknownBaseUnit{Identifier: d.Identifier, BaseUnit: ue.Unit}
+		}
+	}
+	d.UnitExponents = d.UnitExponents.Clone()
+	r.derivedUnits = append(r.derivedUnits, d)
+	if d.Offset != 0 || d.IsLogarithmic() {
+		prefixes = nil
+	}
+	r.byIdentifier[d.Identifier] = registryEntry{base: -1, derived: len(r.derivedUnits) - 1, prefixes: prefixes}
+	r.addPrefixes(prefixes)
+	return nil
+}
+
+// AddAlias registers an alternative identifier of a registered unit, like Ω for ohm
+func (r *Registry) AddAlias(alias string, identifier string) error {
+	r.init()
+	if err := r.checkIdentifier(alias); err != nil {
+		return err
+	}
+	entry, ok := r.byIdentifier[identifier]
+	if !ok {
+		return ErrUnknownAliasTarget{Alias: alias, Identifier: identifier}
+	}
+	if target, ok := r.aliases[identifier]; ok {
+		identifier = target
+	}
+	r.aliases[alias] = identifier
+	r.byIdentifier[alias] = entry
+	return nil
+}
+
+// AddPrefixSet registers a named set of prefixes
+func (r *Registry) AddPrefixSet(name string, prefixes []Prefix) error {
+	r.init()
+	if _, ok := r.prefixSets[name]; ok {
+		return ErrDuplicatePrefixSet{Name: name}
+	}
+	for _, p := range prefixes {
+		if p.Symbol == "" || p.Multiplier <= 0 {
+			return ErrInvalidPrefix{Prefix: p}
+		}
+	}
+	r.prefixSets[name] = append([]Prefix(nil), prefixes...)
+	return nil
+}
+
+// PrefixSet finds a named set of prefixes
+func (r *Registry) PrefixSet(name string) ([]Prefix, bool) {
+	r.init()
+	prefixes, ok := r.prefixSets[name]
+	return prefixes, ok
+}
+
+// PrefixSets lists the names of all prefix sets, sorted
+func (r *Registry) PrefixSets() []string {
+	r.init()
+	res := make([]string, 0, len(r.prefixSets))
+	for name := range r.prefixSets {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// MustAddUnit is like AddUnit but panics on error
+func (r *Registry) MustAddUnit(u U) {
+	if err := r.AddUnit(u); err != nil {
+		panic(err)
+	}
+}
+
+// MustAddDerivedUnit is like AddDerivedUnit but panics on error
+func (r *Registry) MustAddDerivedUnit(d UDerived) {
+	if err := r.AddDerivedUnit(d); err != nil {
+		panic(err)
+	}
+}
+
+// MustAddDerivedUnitWithPrefixes is like AddDerivedUnitWithPrefixes but panics on error
+func (r *Registry) MustAddDerivedUnitWithPrefixes(d UDerived, prefixes []Prefix) {
+	if err := r.AddDerivedUnitWithPrefixes(d, prefixes); err != nil {
+		panic(err)
+	}
+}
+
+// MustAddAlias is like AddAlias but panics on error
+func (r *Registry) MustAddAlias(alias string, identifier string) {
+	if err := r.AddAlias(alias, identifier); err != nil {
+		panic(err)
+	}
+}
+
+// LookupExact resolves a registered identifier or alias into either a base unit or a derived unit.
+//
+// Units found through an alias keep their registered identifier.
+func (r *Registry) LookupExact(identifier string) (base *U, derived *UDerived) {
+	entry, ok := r.byIdentifier[identifier]
+	if !ok {
+		return nil, nil
+	}
+	if entry.base >= 0 {
+		res := r.units[entry.base]
+		return &res, nil
+	}
+	res := r.derivedUnits[entry.derived]
+	res.UnitExponents = res.UnitExponents.Clone()
+	return nil, &res
+}
+
+// Lookup resolves a unit identifier into either a base unit or a derived unit.
+//
+// Identifiers that are not registered are tried as any accepted prefix applied on a registered unit,
+// so there is no need to register prefixed units in advance.
+// Units with an offset can not be prefixed.
+func (r *Registry) Lookup(identifier string) (base *U, derived *UDerived) {
+	if base, derived = r.LookupExact(identifier); base != nil || derived != nil {
+		return
+	}
+	for _, p := range SplitPrefixWith(identifier, r.allPrefixes) {
+		if !hasPrefix(r.byIdentifier[p.Unit].prefixes, p.Prefix) {
+			continue
+		}
+		prefixBase, prefixDerived := r.LookupExact(p.Unit)
+		if prefixBase != nil {
+			res := p.Prefix.Apply(*prefixBase)
+			return nil, &res
+		} else if prefixDerived != nil {
+			res := p.Prefix.ApplyDerived(*prefixDerived)
+			return nil, &res
+		}
+	}
+	return nil, nil
+}
+
+// UnitByID finds the base unit with the given ID
+func (r *Registry) UnitByID(id int) (U, bool) {
+	idx, ok := r.byID[id]
+	if !ok {
+		return U{}, false
+	}
+	return r.units[idx], true
+}
+
+// NextID returns an ID not used by any registered base unit
+func (r *Registry) NextID() int {
+	next := 1
+	for _, u := range r.units {
+		if u.ID >= next {
+			next = u.ID + 1
+		}
+	}
+	return next
+}
+
+// Units lists the registered base units in order of registration
+func (r *Registry) Units() []U {
+	res := make([]U, len(r.units))
+	copy(res, r.units)
+	return res
+}
+
+// DerivedUnits lists the registered derived units in order of registration
+func (r *Registry) DerivedUnits() UDerivedList {
+	return r.derivedUnits.Clone()
+}
+
+// Aliases lists the registered aliases sorted by alias, each with the identifier it refers to
+func (r *Registry) Aliases() []Alias {
+	res := make([]Alias, 0, len(r.aliases))
+	for alias, identifier := range r.aliases {
+		res = append(res, Alias{Alias: alias, Identifier: identifier})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Alias < res[j].Alias
+	})
+	return res
+}
+
+// Identifiers lists all registered identifiers and aliases, sorted
+func (r *Registry) Identifiers() []string {
+	res := make([]string, 0, len(r.byIdentifier))
+	for identifier := range r.byIdentifier {
+		res = append(res, identifier)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Prefixes lists the prefixes accepted by a registered unit
+func (r *Registry) Prefixes(identifier string) []Prefix {
+	return append([]Prefix(nil), r.byIdentifier[identifier].prefixes...)
+}
+
+// FormatOptions creates options for displaying quantities with the units of the registry
+func (r *Registry) FormatOptions(autoDerived bool, autoPrefix bool) (opts FormatOptions) {
+	if autoDerived {
+		opts.AutoDerived = r.DerivedUnits()
+	}
+	opts.AutoPrefix = autoPrefix
+	opts.Prefixes = r.Prefixes
+	return
+}
+
+// Clone creates an independent copy of the registry
+func (r *Registry) Clone() *Registry {
+	res := NewRegistry()
+	res.init()
+	for name, prefixes := range r.prefixSets {
+		res.prefixSets[name] = prefixes
+	}
+	for _, u := range r.units {
+		if err := res.AddUnitWithPrefixes(u, r.byIdentifier[u.Identifier].prefixes); err != nil {
+			panic(err)
+		}
+	}
+	for id, s := range r.substances {
+		res.substances[id] = s
+	}
+	for name := range r.unitSets {
+		res.unitSets[name] = true
+	}
+	for _, d := range r.derivedUnits {
+		if err := res.AddDerivedUnitWithPrefixes(d, r.byIdentifier[d.Identifier].prefixes); err != nil {
+			panic(err)
+		}
+	}
+	for _, a := range r.Aliases() {
+		res.MustAddAlias(a.Alias, a.Identifier)
+	}
+	return res
+}
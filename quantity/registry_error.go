@@ -0,0 +1,285 @@
+package quantity
+
+import (
+	"strings"
+
+	"github.com/eternal-flame-ad/unitdc/localize"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+type ErrDuplicateUnit struct {
+	Identifier string
+}
+
+func (e ErrDuplicateUnit) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_DuplicateUnit",
+			Other: "unit {{.Identifier}} is already defined",
+		},
+		TemplateData: map[string]interface{}{
+			"Identifier": e.Identifier,
+		},
+	})
+}
+
+type ErrInvalidUnitIdentifier struct {
+	Identifier string
+}
+
+func (e ErrInvalidUnitIdentifier) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_InvalidUnitIdentifier",
+			Other: "invalid unit identifier: \"{{.Identifier}}\"",
+		},
+		TemplateData: map[string]interface{}{
+			"Identifier": e.Identifier,
+		},
+	})
+}
+
+type ErrUnitIDConflict struct {
+	ID         int
+	Identifier string
+	Existing   string
+}
+
+func (e ErrUnitIDConflict) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_UnitIDConflict",
+			Other: "unit {{.Identifier}} has the same ID {{.ID}} as unit {{.Existing}}",
+		},
+		TemplateData: map[string]interface{}{
+			"ID":         e.ID,
+			"Identifier": e.Identifier,
+			"Existing":   e.Existing,
+		},
+	})
+}
+
+type ErrUnknownBaseUnit struct {
+	Identifier string
+	BaseUnit   U
+}
+
+func (e ErrUnknownBaseUnit) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_UnknownBaseUnit",
+			Other: "unit {{.Identifier}} is made of unknown base unit {{.BaseUnit}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Identifier": e.Identifier,
+			"BaseUnit":   e.BaseUnit.Identifier,
+		},
+	})
+}
+
+type ErrUnknownAliasTarget struct {
+	Alias      string
+	Identifier string
+}
+
+func (e ErrUnknownAliasTarget) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_UnknownAliasTarget",
+			Other: "alias {{.Alias}} refers to unknown unit {{.Identifier}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Alias":      e.Alias,
+			"Identifier": e.Identifier,
+		},
+	})
+}
+
+type ErrDuplicatePrefixSet struct {
+	Name string
+}
+
+func (e ErrDuplicatePrefixSet) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_DuplicatePrefixSet",
+			Other: "prefix set {{.Name}} is already defined",
+		},
+		TemplateData: map[string]interface{}{
+			"Name": e.Name,
+		},
+	})
+}
+
+type ErrUnknownPrefixSet struct {
+	Name string
+}
+
+func (e ErrUnknownPrefixSet) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_UnknownPrefixSet",
+			Other: "undefined prefix set: {{.Name}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Name": e.Name,
+		},
+	})
+}
+
+type ErrInvalidPrefix struct {
+	Prefix Prefix
+}
+
+func (e ErrInvalidPrefix) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_InvalidPrefix",
+			Other: "invalid prefix \"{{.Symbol}}\" with multiplier {{.Multiplier}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Symbol":     e.Prefix.Symbol,
+			"Multiplier": e.Prefix.Multiplier,
+		},
+	})
+}
+
+type ErrInvalidMultiplier struct {
+	Multiplier float64
+}
+
+func (e ErrInvalidMultiplier) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_InvalidMultiplier",
+			Other: "invalid multiplier {{.Multiplier}}, must be a positive number",
+		},
+		TemplateData: map[string]interface{}{
+			"Multiplier": e.Multiplier,
+		},
+	})
+}
+
+type ErrOffsetInDefinition struct {
+	Identifier string
+}
+
+func (e ErrOffsetInDefinition) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_OffsetInDefinition",
+			Other: "unit {{.Identifier}} has an offset and can not be used to define other units, use its interval unit instead",
+		},
+		TemplateData: map[string]interface{}{
+			"Identifier": e.Identifier,
+		},
+	})
+}
+
+type ErrLogarithmicInDefinition struct {
+	Identifier string
+}
+
+func (e ErrLogarithmicInDefinition) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_LogarithmicInDefinition",
+			Other: "unit {{.Identifier}} is logarithmic and can not be used to define other units",
+		},
+		TemplateData: map[string]interface{}{
+			"Identifier": e.Identifier,
+		},
+	})
+}
+
+type ErrUnknownUnitSet struct {
+	Name string
+}
+
+func (e ErrUnknownUnitSet) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_UnknownUnitSet",
+			Other: "unknown unit set: {{.Name}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Name": e.Name,
+		},
+	})
+}
+
+type ErrUnknownConstant struct {
+	Name string
+}
+
+func (e ErrUnknownConstant) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_UnknownConstant",
+			Other: "unknown constant: {{.Name}}, known constants are {{.Names}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Name":  e.Name,
+			"Names": strings.Join(ConstantNames(), ", "),
+		},
+	})
+}
+
+// ErrDefinition points at the entry of a definition file that caused an error
+type ErrDefinition struct {
+	Entry string
+	Err   error
+}
+
+func (e ErrDefinition) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_Definition",
+			Other: "unit definitions: {{.Entry}}: {{.Error}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Entry": e.Entry,
+			"Error": e.Err.Error(),
+		},
+	})
+}
+
+func (e ErrDefinition) Unwrap() error {
+	return e.Err
+}
+
+type ErrInvalidSubstanceMass struct {
+	Substance string
+	Mass      float64
+}
+
+func (e ErrInvalidSubstanceMass) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_InvalidSubstanceMass",
+			Other: "invalid mass {{.Mass}} (g) per IU of {{.Substance}}, must be a positive number",
+		},
+		TemplateData: map[string]interface{}{
+			"Substance": e.Substance,
+			"Mass":      e.Mass,
+		},
+	})
+}
+
+type ErrSubstanceMismatch struct {
+	From string
+	To   string
+}
+
+func (e ErrSubstanceMismatch) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "QuantityError_SubstanceMismatch",
+			Other: "can not convert IU of {{.From}} into IU of {{.To}}",
+		},
+		TemplateData: map[string]interface{}{
+			"From": e.From,
+			"To":   e.To,
+		},
+	})
+}
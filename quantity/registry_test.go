@@ -0,0 +1,72 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRegistry(t *testing.T) {
+	Convey("Registry", t, func() {
+		r := NewDefaultRegistry()
+
+		Convey("should look up units and aliases", func() {
+			base, derived := r.LookupExact("g")
+			So(derived, ShouldBeNil)
+			So(*base, ShouldResemble, UnitGram)
+
+			base, derived = r.LookupExact("Ω")
+			So(base, ShouldBeNil)
+			So(derived.Identifier, ShouldEqual, "ohm")
+
+			_, derived = r.Lookup("kΩ")
+			So(derived.Identifier, ShouldEqual, "kohm")
+			So(derived.Multiplier, ShouldAlmostEqual, UnitDerivedOhm.Multiplier*1e3)
+
+			_, derived = r.Lookup("mL")
+			So(derived.Identifier, ShouldEqual, "ml")
+
+			base, derived = r.Lookup("kdegC")
+			So(base, ShouldBeNil)
+			So(derived, ShouldBeNil)
+		})
+		Convey("should reject duplicates and conflicts", func() {
+			So(r.AddUnit(U{Identifier: "g", ID: 100}), ShouldResemble, ErrDuplicateUnit{Identifier: "g"})
+			So(r.AddAlias("Ω", "ohm"), ShouldResemble, ErrDuplicateUnit{Identifier: "Ω"})
+			So(r.AddDerivedUnit(NewUDerived("N", 1, nil)), ShouldResemble, ErrDuplicateUnit{Identifier: "N"})
+			So(r.AddUnit(U{Identifier: "cell", ID: UnitGram.ID}), ShouldResemble,
+				ErrUnitIDConflict{ID: UnitGram.ID, Identifier: "cell", Existing: "g"})
+			So(r.AddUnit(U{Identifier: "1", ID: 100}), ShouldResemble, ErrInvalidUnitIdentifier{Identifier: "1"})
+			So(r.AddAlias("gram", "gramm"), ShouldResemble, ErrUnknownAliasTarget{Alias: "gram", Identifier: "gramm"})
+
+			cell := U{Identifier: "cell", ID: 100}
+			So(r.AddDerivedUnit(NewUDerived("kcell", 1e3, UCombination{{Unit: cell, Exponent: IntExponent(1)}})),
+				ShouldResemble, ErrUnknownBaseUnit{Identifier: "kcell", BaseUnit: cell})
+
+			base, derived := r.LookupExact("cell")
+			So(base, ShouldBeNil)
+			So(derived, ShouldBeNil)
+		})
+		Convey("should enumerate units", func() {
+			So(r.Units()[:len(BuiltinUnits)], ShouldResemble, BuiltinUnits)
+			So(r.Units(), ShouldHaveLength, len(BuiltinUnits)+len(BuiltinSubstances))
+			So(r.DerivedUnits(), ShouldHaveLength, len(BuiltinDerivedUnits)+len(UnitDerivedTime)+len(UnitDerivedRatio))
+			So(r.Aliases(), ShouldHaveLength, len(BuiltinAliases))
+			So(r.Identifiers(), ShouldContain, "Δ°C")
+			So(r.NextID(), ShouldEqual, UnitCandela.ID+len(BuiltinSubstances)+1)
+
+			u, ok := r.UnitByID(UnitMole.ID)
+			So(ok, ShouldBeTrue)
+			So(u, ShouldResemble, UnitMole)
+		})
+		Convey("clones should be independent", func() {
+			clone := r.Clone()
+			So(clone.AddUnit(U{Identifier: "cell", ID: clone.NextID()}), ShouldBeNil)
+			So(clone.AddAlias("cells", "cell"), ShouldBeNil)
+			base, _ := clone.LookupExact("cells")
+			So(base.Identifier, ShouldEqual, "cell")
+			base, _ = r.LookupExact("cell")
+			So(base, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package quantity
+
+// Scale tells whether a quantity on an affine scale, like a temperature in °C,
+// is an absolute point on the scale or a difference between two points.
+type Scale int
+
+const (
+	// ScaleRatio is a quantity where the distinction does not matter,
+	// like any quantity without offset units or a temperature in kelvin
+	ScaleRatio Scale = iota
+	// ScaleAbsolute is an absolute point on an affine scale, like 20 °C
+	ScaleAbsolute
+	// ScaleInterval is a difference on an affine scale, like 5 Δ°C
+	ScaleInterval
+)
+
+// AddScales finds the scale of the sum of two quantities,
+// ok is false if both quantities are absolute.
+//
+// A difference added to a ratio quantity, like 300 K + 5 Δ°C, is a ratio quantity.
+func AddScales(s1 Scale, s2 Scale) (res Scale, ok bool) {
+	switch {
+	case s1 == ScaleAbsolute && s2 == ScaleAbsolute:
+		return ScaleRatio, false
+	case s1 == ScaleAbsolute || s2 == ScaleAbsolute:
+		return ScaleAbsolute, true
+	case s1 == ScaleInterval && s2 == ScaleInterval:
+		return ScaleInterval, true
+	}
+	return ScaleRatio, true
+}
+
+// SubScales finds the scale of the difference s1 - s2 of two quantities,
+// ok is false if an absolute quantity is subtracted from a difference.
+//
+// An absolute quantity subtracted from a ratio quantity, like 300 K - 20 °C, is a difference,
+// and a difference subtracted from a ratio quantity, like 300 K - 5 Δ°C, is a ratio quantity.
+func SubScales(s1 Scale, s2 Scale) (res Scale, ok bool) {
+	switch {
+	case s2 == ScaleAbsolute && s1 == ScaleInterval:
+		return ScaleRatio, false
+	case s2 == ScaleAbsolute:
+		return ScaleInterval, true
+	case s1 == ScaleAbsolute:
+		return ScaleAbsolute, true
+	case s1 == ScaleInterval && s2 == ScaleInterval:
+		return ScaleInterval, true
+	}
+	return ScaleRatio, true
+}
+
+// MulScales finds the scale of the product or quotient of two quantities,
+// ok is false if an absolute quantity is combined with a quantity with units, like 20 °C / 1 min.
+//
+// Only differences scaled by a unitless factor stay differences,
+// everything else is computed on the underlying ratio scale (e.g. kelvin).
+func MulScales(q1 Q, q2 Q) (res Scale, ok bool) {
+	if q1.Scale == ScaleAbsolute && !q2.UnitExponents.IsNoUnit() ||
+		q2.Scale == ScaleAbsolute && !q1.UnitExponents.IsNoUnit() {
+		return ScaleRatio, false
+	}
+	if q1.Scale == ScaleInterval && q2.UnitExponents.IsNoUnit() ||
+		q2.Scale == ScaleInterval && q1.UnitExponents.IsNoUnit() {
+		return ScaleInterval, true
+	}
+	return ScaleRatio, true
+}
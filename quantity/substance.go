@@ -0,0 +1,142 @@
+package quantity
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// SubstanceIUPrefix starts the identifier of the IU unit of a substance, like iu:vitD3
+const SubstanceIUPrefix = "iu:"
+
+// Substance is a substance measured in International Units (IU).
+//
+// The mass of one IU is different for every substance, so the IU of each substance is a base unit of its own,
+// identified by SubstanceIUPrefix followed by the name of the substance.
+// The generic (iu) unit does not refer to any substance and can not be converted to mass.
+type Substance struct {
+	Name string `json:"name"`
+	// Mass is the mass of one IU of the substance in grams
+	Mass float64 `json:"mass"`
+}
+
+// IUIdentifier is the identifier of the IU unit of the substance
+func (s Substance) IUIdentifier() string {
+	return SubstanceIUPrefix + s.Name
+}
+
+// BuiltinSubstances lists the substances with a well-defined mass per IU.
+//
+// Biologicals like heparin are standardized by activity, and the mass of one IU depends on the preparation,
+// so they are better defined in a definition file for the product at hand.
+var BuiltinSubstances = []Substance{
+	// retinol
+	{Name: "vitA", Mass: 0.3e-6},
+	{Name: "betacarotene", Mass: 0.6e-6},
+	// ergocalciferol and cholecalciferol
+	{Name: "vitD2", Mass: 0.025e-6},
+	{Name: "vitD3", Mass: 0.025e-6},
+	// d-alpha-tocopherol
+	{Name: "vitE", Mass: 0.67e-3},
+	// human insulin, 28.8 IU/mg
+	{Name: "insulin", Mass: 1e-3 / 28.8},
+	// benzylpenicillin sodium
+	{Name: "penicillinG", Mass: 0.6e-6},
+}
+
+// AddSubstance registers a substance and the base unit for its IU, accepting SI prefixes
+func (r *Registry) AddSubstance(s Substance) error {
+	r.init()
+	if s.Mass <= 0 || math.IsInf(s.Mass, 0) || math.IsNaN(s.Mass) {
+		return ErrInvalidSubstanceMass{Substance: s.Name, Mass: s.Mass}
+	}
+	if s.Name == "" || strings.HasPrefix(s.Name, SubstanceIUPrefix) {
+		return ErrInvalidUnitIdentifier{Identifier: s.IUIdentifier()}
+	}
+	u := U{Identifier: s.IUIdentifier(), ID: r.NextID()}
+	if err := r.AddUnit(u); err != nil {
+		return err
+	}
+	r.substances[u.ID] = s
+	return nil
+}
+
+// MustAddSubstance is like AddSubstance but panics on error
+func (r *Registry) MustAddSubstance(s Substance) {
+	if err := r.AddSubstance(s); err != nil {
+		panic(err)
+	}
+}
+
+// Substance finds the substance whose IU is the given base unit
+func (r *Registry) Substance(u U) (Substance, bool) {
+	s, ok := r.substances[u.ID]
+	if !ok || r.units[r.byID[u.ID]] != u {
+		return Substance{}, false
+	}
+	return s, true
+}
+
+// Substances lists the registered substances, sorted by name
+func (r *Registry) Substances() []Substance {
+	res := make([]Substance, 0, len(r.substances))
+	for _, s := range r.substances {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// ConvertSubstance converts a quantity between mass and the IU of a substance,
+// when the units of target are exactly grams or exactly the IU of a substance.
+//
+// Converting into IU replaces the grams in the quantity, like (ug)(ml)-1 into (iu:vitD3)(ml)-1,
+// and converting into mass replaces the IU of any substance.
+// Converting into the IU of a substance when the quantity is in IU of another substance is an error.
+//
+// The quantity is returned unchanged if there is nothing to convert.
+func (r *Registry) ConvertSubstance(q Q, target UCombination) (Q, error) {
+	if len(target) != 1 || target[0].Exponent != IntExponent(1) {
+		return q, nil
+	}
+	to, toSubstance := r.Substance(target[0].Unit)
+	toMass := target[0].Unit == UnitGram
+	if !toSubstance && !toMass {
+		return q, nil
+	}
+
+	res := q
+	res.Number = q.Value()
+	res.UnitExponents = q.UnitExponents.Clone()
+	converted := false
+	for i, ue := range res.UnitExponents {
+		switch from, ok := r.Substance(ue.Unit); {
+		case ok && toSubstance && from != to:
+			return q, ErrSubstanceMismatch{From: from.Name, To: to.Name}
+		case ok && toMass:
+			// x (iu)^e = x mass^e (g)^e
+			res.Number = res.Number.Mul(PowNumber(res.Number.convert(Float(from.Mass)), ue.Exponent))
+			res.Uncertainty *= math.Pow(from.Mass, ue.Exponent.Float64())
+			res.UnitExponents[i].Unit = UnitGram
+			converted = true
+		}
+	}
+	if toSubstance {
+		for i, ue := range res.UnitExponents {
+			if ue.Unit == UnitGram {
+				// x (g)^e = x mass^-e (iu)^e
+				res.Number = res.Number.Quo(PowNumber(res.Number.convert(Float(to.Mass)), ue.Exponent))
+				res.Uncertainty /= math.Pow(to.Mass, ue.Exponent.Float64())
+				res.UnitExponents[i].Unit = target[0].Unit
+				converted = true
+			}
+		}
+	}
+	if !converted {
+		return q, nil
+	}
+	res.UnitExponents.Simplify()
+	return res, nil
+}
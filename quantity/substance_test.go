@@ -0,0 +1,72 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSubstance(t *testing.T) {
+	Convey("Substance IU", t, func() {
+		r := NewDefaultRegistry()
+		vitD3, _ := r.LookupExact("iu:vitD3")
+		vitD2, _ := r.LookupExact("iu:vitD2")
+		_, microgram := r.Lookup("ug")
+		_, milliliter := r.Lookup("ml")
+
+		Convey("should be registered as base units", func() {
+			So(vitD3, ShouldNotBeNil)
+			s, ok := r.Substance(*vitD3)
+			So(ok, ShouldBeTrue)
+			So(s.Name, ShouldEqual, "vitD3")
+			_, ok = r.Substance(UnitIU)
+			So(ok, ShouldBeFalse)
+
+			_, milliIU := r.Lookup("miu:insulin")
+			So(milliIU, ShouldNotBeNil)
+			So(milliIU.Multiplier, ShouldEqual, 1e-3)
+		})
+		Convey("should convert into mass", func() {
+			q := Q{Number: Float(400), Uncertainty: 40, UnitExponents: UCombination{{Unit: *vitD3, Exponent: IntExponent(1)}}}
+			res, err := r.ConvertSubstance(q, microgram.UnitExponents)
+			So(err, ShouldBeNil)
+			So(res.Number.Float64(), ShouldAlmostEqual, 1e-5)
+			So(res.Uncertainty, ShouldAlmostEqual, 1e-6)
+			So(res.UnitExponents, ShouldResemble, UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}})
+		})
+		Convey("should convert concentrations from mass", func() {
+			q := Q{Number: Float(1e-2), UnitExponents: UCombination{
+				{Unit: UnitGram, Exponent: IntExponent(1)},
+				{Unit: UnitLiter, Exponent: IntExponent(-1)},
+			}}
+			res, err := r.ConvertSubstance(q, UCombination{{Unit: *vitD3, Exponent: IntExponent(1)}})
+			So(err, ShouldBeNil)
+			So(res.Number.Float64(), ShouldAlmostEqual, 4e5)
+			expected := UCombination{
+				{Unit: *vitD3, Exponent: IntExponent(1)},
+				{Unit: UnitLiter, Exponent: IntExponent(-1)},
+			}
+			So(res.UnitExponents.Equal(&expected), ShouldBeTrue)
+		})
+		Convey("should refuse to convert between substances", func() {
+			q := Q{Number: Float(400), UnitExponents: UCombination{{Unit: *vitD3, Exponent: IntExponent(1)}}}
+			_, err := r.ConvertSubstance(q, UCombination{{Unit: *vitD2, Exponent: IntExponent(1)}})
+			So(err, ShouldHaveSameTypeAs, ErrSubstanceMismatch{})
+		})
+		Convey("should leave other quantities alone", func() {
+			q := Q{Number: Float(1), UnitExponents: UCombination{{Unit: UnitLiter, Exponent: IntExponent(1)}}}
+			res, err := r.ConvertSubstance(q, milliliter.UnitExponents)
+			So(err, ShouldBeNil)
+			So(res, ShouldResemble, q)
+
+			q = Q{Number: Float(1), UnitExponents: UCombination{{Unit: UnitIU, Exponent: IntExponent(1)}}}
+			res, err = r.ConvertSubstance(q, microgram.UnitExponents)
+			So(err, ShouldBeNil)
+			So(res, ShouldResemble, q)
+		})
+		Convey("should reject invalid masses", func() {
+			So(r.AddSubstance(Substance{Name: "heparin", Mass: 0}), ShouldHaveSameTypeAs, ErrInvalidSubstanceMass{})
+			So(r.AddSubstance(Substance{Name: "vitD3", Mass: 1}), ShouldHaveSameTypeAs, ErrDuplicateUnit{})
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package quantity
+
+import (
+	"math"
+	"strconv"
+)
+
+// The following functions propagate standard uncertainties to first order,
+// assuming the operands are uncorrelated.
+
+// SumUncertainty propagates the uncertainties of x + y or x - y
+func SumUncertainty(ux float64, uy float64) float64 {
+	return math.Hypot(ux, uy)
+}
+
+// ProductUncertainty propagates the uncertainties of x * y
+func ProductUncertainty(x float64, ux float64, y float64, uy float64) float64 {
+	if ux == 0 && uy == 0 {
+		return 0
+	}
+	return math.Hypot(y*ux, x*uy)
+}
+
+// QuotientUncertainty propagates the uncertainties of x / y
+func QuotientUncertainty(x float64, ux float64, y float64, uy float64) float64 {
+	if ux == 0 && uy == 0 {
+		return 0
+	}
+	return math.Hypot(ux/y, x*uy/(y*y))
+}
+
+// SqrtUncertainty propagates the uncertainty of the square root of x
+func SqrtUncertainty(x float64, ux float64) float64 {
+	if ux == 0 {
+		return 0
+	}
+	return ux / (2 * math.Sqrt(x))
+}
+
+// UncertaintySignificantDigits is the number of significant digits an uncertainty is rounded to
+const UncertaintySignificantDigits = 2
+
+// FormatUncertain formats a value with its uncertainty, like 1.500±0.020.
+//
+// The uncertainty is rounded to UncertaintySignificantDigits significant digits,
+// and the value is rounded to the same decimal place. Very small or large values
+// are formatted in scientific notation, like 9.1093837015e-31±2.8e-40.
+func FormatUncertain(num float64, uncertainty float64) string {
+	uncertainty = math.Abs(uncertainty)
+	if uncertainty == 0 || math.IsNaN(uncertainty) || math.IsInf(uncertainty, 0) {
+		return strconv.FormatFloat(num, 'g', -1, 64) + "±" + strconv.FormatFloat(uncertainty, 'g', -1, 64)
+	}
+	uncertaintyExp := int(math.Floor(math.Log10(uncertainty)))
+	if num != 0 && !math.IsNaN(num) && !math.IsInf(num, 0) {
+		// the same thresholds as the 'g' format of strconv
+		if exp := int(math.Floor(math.Log10(math.Abs(num)))); exp < -4 || exp >= 21 {
+			digits := exp - uncertaintyExp + UncertaintySignificantDigits - 1
+			if digits < 0 {
+				digits = 0
+			}
+			return strconv.FormatFloat(num, 'e', digits, 64) + "±" + strconv.FormatFloat(uncertainty, 'e', UncertaintySignificantDigits-1, 64)
+		}
+	}
+	decimals := UncertaintySignificantDigits - 1 - uncertaintyExp
+	if decimals >= 0 {
+		return strconv.FormatFloat(num, 'f', decimals, 64) + "±" + strconv.FormatFloat(uncertainty, 'f', decimals, 64)
+	}
+	scale := math.Pow10(-decimals)
+	return strconv.FormatFloat(math.Round(num/scale)*scale, 'f', 0, 64) +
+		"±" + strconv.FormatFloat(math.Round(uncertainty/scale)*scale, 'f', 0, 64)
+}
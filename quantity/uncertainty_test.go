@@ -0,0 +1,39 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUncertainty(t *testing.T) {
+	Convey("Uncertainty", t, func() {
+		Convey("should propagate to first order", func() {
+			So(SumUncertainty(3, 4), ShouldAlmostEqual, 5)
+			// relative uncertainties add in quadrature: 3% and 4%
+			So(ProductUncertainty(10, 0.3, 2, 0.08), ShouldAlmostEqual, 20*0.05)
+			So(QuotientUncertainty(10, 0.3, 2, 0.08), ShouldAlmostEqual, 5*0.05)
+			So(QuotientUncertainty(0, 0, 0, 0), ShouldEqual, 0)
+			So(SqrtUncertainty(4, 0.4), ShouldAlmostEqual, 0.1)
+		})
+		Convey("should round to significant digits of the uncertainty", func() {
+			So(FormatUncertain(1.5, 0.02), ShouldEqual, "1.500±0.020")
+			So(FormatUncertain(1.23456, 0.000123), ShouldEqual, "1.23456±0.00012")
+			So(FormatUncertain(12345.6, 234), ShouldEqual, "12350±230")
+			So(FormatUncertain(9.1093837015e-31, 2.8e-40), ShouldEqual, "9.1093837015e-31±2.8e-40")
+			So(FormatUncertain(6.0221e23, 1.2e20), ShouldEqual, "6.0221e+23±1.2e+20")
+		})
+		Convey("should scale with display units", func() {
+			milli, _ := LookupPrefix("m")
+			q := Q{
+				Number:            Float(1.5e-3),
+				Uncertainty:       2e-5,
+				UnitExponents:     UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}},
+				DerivedUnitsToUse: UDerivedList{milli.Apply(UnitGram)},
+			}
+			d := q.Display()
+			So(d.Units[0].Identifier, ShouldEqual, "mg")
+			So(d.NumberString(false), ShouldEqual, "1.500±0.020")
+		})
+	})
+}
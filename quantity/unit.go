@@ -2,7 +2,7 @@ package quantity
 
 type UExp struct {
 	Unit     U
-	Exponent int
+	Exponent Exponent
 }
 
 type U struct {
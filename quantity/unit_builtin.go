@@ -1,5 +1,7 @@
 package quantity
 
+import "math/big"
+
 var (
 	UnitGram = U{
 		Identifier: "g",
@@ -21,25 +23,38 @@ var (
 		Identifier: "mol",
 		ID:         5,
 	}
+	UnitSecond = U{
+		Identifier: "s",
+		ID:         6,
+	}
+	UnitKelvin = U{
+		Identifier: "K",
+		ID:         7,
+	}
+	UnitAmpere = U{
+		Identifier: "A",
+		ID:         8,
+	}
+	UnitCandela = U{
+		Identifier: "cd",
+		ID:         9,
+	}
 )
 
+// BuiltinUnits lists all builtin base units
+var BuiltinUnits = []U{
+	UnitGram,
+	UnitLiter,
+	UnitIU,
+	UnitMeter,
+	UnitMole,
+	UnitSecond,
+	UnitKelvin,
+	UnitAmpere,
+	UnitCandela,
+}
+
 var (
-	UnitDerivedGramEng = DeriveUnitWithEngineeringSymbolList(
-		UnitGram,
-		"m", "u", "n", "p",
-	)
-	UnitDerivedLiterEng = DeriveUnitWithEngineeringSymbolList(
-		UnitLiter,
-		"d", "m", "u", "n",
-	)
-	UnitDerivedMeterEng = DeriveUnitWithEngineeringSymbolList(
-		UnitMeter,
-		"c", "m", "u", "n",
-	)
-	UnitDerivedMoleEng = DeriveUnitWithEngineeringSymbolList(
-		UnitMole,
-		"m", "u", "n", "p",
-	)
 	UnitDerivedAmu = func() UDerived {
 		res := UDerived{
 			Offset:     0,
@@ -48,21 +63,17 @@ var (
 			UnitExponents: UCombination{
 				{
 					Unit:     UnitGram,
-					Exponent: 1,
+					Exponent: IntExponent(1),
 				},
 				{
 					Unit:     UnitMole,
-					Exponent: -1,
+					Exponent: IntExponent(-1),
 				},
 			},
 		}
 		res.UnitExponents.Simplify()
 		return res
 	}()
-	UnitDerivedAmuEng = DeriveUnitWithEnginneringSymbolOnDerivedUnitList(
-		UnitDerivedAmu,
-		"k",
-	)
 	UnitDerivedMolar = func() UDerived {
 		res := UDerived{
 			Offset:     0,
@@ -71,19 +82,294 @@ var (
 			UnitExponents: UCombination{
 				{
 					Unit:     UnitMole,
-					Exponent: 1,
+					Exponent: IntExponent(1),
 				},
 				{
 					Unit:     UnitLiter,
-					Exponent: -1,
+					Exponent: IntExponent(-1),
 				},
 			},
 		}
 		res.UnitExponents.Simplify()
 		return res
 	}()
+)
+
+// Prefixed units that were registered before prefixes were resolved on demand.
+//
+// Deprecated: any SI prefix is accepted on registered units, see Registry.Lookup.
+// Use DeriveUnitWithEngineeringSymbol or Prefix.Apply to derive a prefixed unit.
+var (
+	UnitDerivedGramEng = DeriveUnitWithEngineeringSymbolList(
+		UnitGram,
+		"m", "u", "n", "p",
+	)
+	UnitDerivedLiterEng = DeriveUnitWithEngineeringSymbolList(
+		UnitLiter,
+		"d", "m", "u", "n",
+	)
+	UnitDerivedMeterEng = DeriveUnitWithEngineeringSymbolList(
+		UnitMeter,
+		"c", "m", "u", "n",
+	)
+	UnitDerivedMoleEng = DeriveUnitWithEngineeringSymbolList(
+		UnitMole,
+		"m", "u", "n", "p",
+	)
+	UnitDerivedAmuEng = DeriveUnitWithEnginneringSymbolOnDerivedUnitList(
+		UnitDerivedAmu,
+		"k",
+	)
 	UnitDerivedMolarEng = DeriveUnitWithEnginneringSymbolOnDerivedUnitList(
 		UnitDerivedMolar,
 		"m", "u", "n", "p",
 	)
 )
+
+// SI derived units with special names.
+//
+// Since the base unit of mass is the gram, everything that is defined
+// in terms of the kilogram carries the corresponding multiplier.
+//
+// Units meant for specific kinds of quantities, like Gy for absorbed dose
+// or Bq for radioactivity, are never chosen automatically for display.
+var (
+	UnitDerivedHertz = NewUDerived("Hz", 1, UCombination{
+		{Unit: UnitSecond, Exponent: IntExponent(-1)},
+	})
+	UnitDerivedNewton = NewUDerived("N", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(1)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+	})
+	UnitDerivedPascal = NewUDerived("Pa", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(-1)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+	})
+	UnitDerivedJoule = NewUDerived("J", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+	})
+	UnitDerivedWatt = NewUDerived("W", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-3)},
+	})
+	UnitDerivedCoulomb = NewUDerived("C", 1, UCombination{
+		{Unit: UnitAmpere, Exponent: IntExponent(1)},
+		{Unit: UnitSecond, Exponent: IntExponent(1)},
+	})
+	UnitDerivedVolt = NewUDerived("V", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-3)},
+		{Unit: UnitAmpere, Exponent: IntExponent(-1)},
+	})
+	UnitDerivedFarad = NewUDerived("F", 1e-3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(-1)},
+		{Unit: UnitMeter, Exponent: IntExponent(-2)},
+		{Unit: UnitSecond, Exponent: IntExponent(4)},
+		{Unit: UnitAmpere, Exponent: IntExponent(2)},
+	})
+	UnitDerivedOhm = NewUDerived("ohm", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-3)},
+		{Unit: UnitAmpere, Exponent: IntExponent(-2)},
+	})
+	UnitDerivedSiemens = NewUDerived("S", 1e-3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(-1)},
+		{Unit: UnitMeter, Exponent: IntExponent(-2)},
+		{Unit: UnitSecond, Exponent: IntExponent(3)},
+		{Unit: UnitAmpere, Exponent: IntExponent(2)},
+	})
+	UnitDerivedWeber = NewUDerived("Wb", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+		{Unit: UnitAmpere, Exponent: IntExponent(-1)},
+	})
+	UnitDerivedTesla = NewUDerived("T", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+		{Unit: UnitAmpere, Exponent: IntExponent(-1)},
+	})
+	UnitDerivedHenry = NewUDerived("H", 1e3, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+		{Unit: UnitAmpere, Exponent: IntExponent(-2)},
+	})
+	// the steradian is dimensionless, so a lumen is
+	// indistinguishable from a candela.
+	UnitDerivedLumen = NewUDerived("lm", 1, UCombination{
+		{Unit: UnitCandela, Exponent: IntExponent(1)},
+	}).ExplicitOnlyUnit()
+	UnitDerivedLux = NewUDerived("lx", 1, UCombination{
+		{Unit: UnitCandela, Exponent: IntExponent(1)},
+		{Unit: UnitMeter, Exponent: IntExponent(-2)},
+	})
+	UnitDerivedBecquerel = NewUDerived("Bq", 1, UCombination{
+		{Unit: UnitSecond, Exponent: IntExponent(-1)},
+	}).ExplicitOnlyUnit()
+	UnitDerivedGray = NewUDerived("Gy", 1, UCombination{
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+	}).ExplicitOnlyUnit()
+	UnitDerivedSievert = NewUDerived("Sv", 1, UCombination{
+		{Unit: UnitMeter, Exponent: IntExponent(2)},
+		{Unit: UnitSecond, Exponent: IntExponent(-2)},
+	}).ExplicitOnlyUnit()
+	UnitDerivedKatal = NewUDerived("kat", 1, UCombination{
+		{Unit: UnitMole, Exponent: IntExponent(1)},
+		{Unit: UnitSecond, Exponent: IntExponent(-1)},
+	}).ExplicitOnlyUnit()
+)
+
+// UnitDerivedSI lists all SI derived units with special names
+// defined in this package.
+var UnitDerivedSI = UDerivedList{
+	UnitDerivedHertz,
+	UnitDerivedNewton,
+	UnitDerivedPascal,
+	UnitDerivedJoule,
+	UnitDerivedWatt,
+	UnitDerivedCoulomb,
+	UnitDerivedVolt,
+	UnitDerivedFarad,
+	UnitDerivedOhm,
+	UnitDerivedSiemens,
+	UnitDerivedWeber,
+	UnitDerivedTesla,
+	UnitDerivedHenry,
+	UnitDerivedLumen,
+	UnitDerivedLux,
+	UnitDerivedBecquerel,
+	UnitDerivedGray,
+	UnitDerivedSievert,
+	UnitDerivedKatal,
+}
+
+// Temperature scales with an offset, and the corresponding units for temperature differences.
+var (
+	UnitDerivedCelsius = UDerived{
+		Identifier:    "degC",
+		Offset:        273.15,
+		Multiplier:    1,
+		UnitExponents: UCombination{{Unit: UnitKelvin, Exponent: IntExponent(1)}},
+	}
+	UnitDerivedFahrenheit = UDerived{
+		Identifier:    "degF",
+		UnitExponents: UCombination{{Unit: UnitKelvin, Exponent: IntExponent(1)}},
+	}.WithExactFactors(big.NewRat(5, 9), big.NewRat(45967, 180)) // 459.67 * 5/9
+	UnitDerivedCelsiusInterval    = UnitDerivedCelsius.IntervalUnit()
+	UnitDerivedFahrenheitInterval = UnitDerivedFahrenheit.IntervalUnit()
+)
+
+// UnitDerivedTemperature lists all temperature units with an offset and their differences
+var UnitDerivedTemperature = UDerivedList{
+	UnitDerivedCelsius,
+	UnitDerivedFahrenheit,
+	UnitDerivedCelsiusInterval,
+	UnitDerivedFahrenheitInterval,
+}
+
+// Units of time accepted for use with the SI.
+//
+// They are only used for display when chosen explicitly, like (ul)(min)-1 for a flow rate,
+// otherwise time is displayed in seconds.
+var (
+	UnitDerivedMinute = NewUDerived("min", 60, UCombination{
+		{Unit: UnitSecond, Exponent: IntExponent(1)},
+	}).ExplicitOnlyUnit()
+	UnitDerivedHour = NewUDerived("h", 3600, UCombination{
+		{Unit: UnitSecond, Exponent: IntExponent(1)},
+	}).ExplicitOnlyUnit()
+	UnitDerivedDay = NewUDerived("day", 86400, UCombination{
+		{Unit: UnitSecond, Exponent: IntExponent(1)},
+	}).ExplicitOnlyUnit()
+	UnitDerivedWeek = NewUDerived("week", 604800, UCombination{
+		{Unit: UnitSecond, Exponent: IntExponent(1)},
+	}).ExplicitOnlyUnit()
+)
+
+// UnitDerivedTime lists all units of time other than the second, they do not accept prefixes
+var UnitDerivedTime = UDerivedList{
+	UnitDerivedMinute,
+	UnitDerivedHour,
+	UnitDerivedDay,
+	UnitDerivedWeek,
+}
+
+// Dimensionless ratios, and the concentration conventions of the lab.
+//
+// ppt is parts per trillion, use ‰ for parts per thousand.
+// A percentage weight per volume (% w/v) is grams per 100 ml, it is only used when asked for,
+// percentages by volume (% v/v) and by weight (% w/w) are plain ratios.
+var (
+	UnitDerivedPercent       = NewUDerived("%", 1e-2, UCombination{})
+	UnitDerivedPermille      = NewUDerived("‰", 1e-3, UCombination{})
+	UnitDerivedPPM           = NewUDerived("ppm", 1e-6, UCombination{})
+	UnitDerivedPPB           = NewUDerived("ppb", 1e-9, UCombination{})
+	UnitDerivedPPT           = NewUDerived("ppt", 1e-12, UCombination{})
+	UnitDerivedPercentVolume = NewUDerived("%v/v", 1e-2, UCombination{})
+	UnitDerivedPercentWeight = NewUDerived("%w/w", 1e-2, UCombination{})
+	UnitDerivedPercentWV     = NewUDerived("%w/v", 10, UCombination{
+		{Unit: UnitGram, Exponent: IntExponent(1)},
+		{Unit: UnitLiter, Exponent: IntExponent(-1)},
+	}).ExplicitOnlyUnit()
+)
+
+// UnitDerivedRatio lists all ratio units, they do not accept prefixes
+var UnitDerivedRatio = UDerivedList{
+	UnitDerivedPercent,
+	UnitDerivedPermille,
+	UnitDerivedPPM,
+	UnitDerivedPPB,
+	UnitDerivedPPT,
+	UnitDerivedPercentVolume,
+	UnitDerivedPercentWeight,
+	UnitDerivedPercentWV,
+}
+
+// Logarithmic units, see NewLogarithmicUnit.
+//
+// pH and pKa are relative to 1 (M), dBm to 1 (mW), which is 1 (g)(m)2(s)-3 as the base unit of mass is the gram.
+// Note that pH takes precedence over picohenry.
+var (
+	UnitDerivedPH      = NewLogarithmicUnit("pH", -1, 1, UnitDerivedMolar.UnitExponents)
+	UnitDerivedPKa     = NewLogarithmicUnit("pKa", -1, 1, UnitDerivedMolar.UnitExponents)
+	UnitDerivedDecibel = NewLogarithmicUnit("dB", 10, 1, UCombination{})
+	UnitDerivedDBm     = NewLogarithmicUnit("dBm", 10, 1, UnitDerivedWatt.UnitExponents)
+)
+
+// UnitDerivedLogarithmic lists all logarithmic units
+var UnitDerivedLogarithmic = UDerivedList{
+	UnitDerivedPH,
+	UnitDerivedPKa,
+	UnitDerivedDecibel,
+	UnitDerivedDBm,
+}
+
+// BuiltinDerivedUnits lists all builtin derived units accepting SI prefixes
+var BuiltinDerivedUnits = func() (res UDerivedList) {
+	res = append(res, UnitDerivedAmu)
+	res = append(res, UnitDerivedMolar)
+	res = append(res, UnitDerivedSI...)
+	res = append(res, UnitDerivedTemperature...)
+	res = append(res, UnitDerivedLogarithmic...)
+	return
+}()
+
+// BuiltinAliases lists alternative spellings of builtin units
+var BuiltinAliases = []Alias{
+	{Alias: "L", Identifier: UnitLiter.Identifier},
+	{Alias: "Ω", Identifier: UnitDerivedOhm.Identifier},
+	{Alias: "°C", Identifier: UnitDerivedCelsius.Identifier},
+	{Alias: "°F", Identifier: UnitDerivedFahrenheit.Identifier},
+	{Alias: "Δ°C", Identifier: UnitDerivedCelsiusInterval.Identifier},
+	{Alias: "Δ°F", Identifier: UnitDerivedFahrenheitInterval.Identifier},
+	{Alias: "permille", Identifier: UnitDerivedPermille.Identifier},
+}
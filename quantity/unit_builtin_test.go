@@ -0,0 +1,131 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBuiltinSIUnits(t *testing.T) {
+	Convey("SI derived units", t, func() {
+		Convey("should be consistent with each other", func() {
+			mul := func(a, b UCombination) UCombination {
+				res := append(a.Clone(), b...)
+				res.Simplify()
+				return res
+			}
+			div := func(a, b UCombination) UCombination {
+				inv := b.Clone()
+				inv.Inverse()
+				return mul(a, inv)
+			}
+			cases := []struct {
+				Derived UDerived
+				Comb    UCombination
+			}{
+				{UnitDerivedJoule, mul(UnitDerivedNewton.UnitExponents, UCombination{{Unit: UnitMeter, Exponent: IntExponent(1)}})},
+				{UnitDerivedWatt, div(UnitDerivedJoule.UnitExponents, UCombination{{Unit: UnitSecond, Exponent: IntExponent(1)}})},
+				{UnitDerivedPascal, div(UnitDerivedNewton.UnitExponents, UCombination{{Unit: UnitMeter, Exponent: IntExponent(2)}})},
+				{UnitDerivedVolt, div(UnitDerivedWatt.UnitExponents, UCombination{{Unit: UnitAmpere, Exponent: IntExponent(1)}})},
+				{UnitDerivedOhm, div(UnitDerivedVolt.UnitExponents, UCombination{{Unit: UnitAmpere, Exponent: IntExponent(1)}})},
+				{UnitDerivedFarad, div(UnitDerivedCoulomb.UnitExponents, UnitDerivedVolt.UnitExponents)},
+				{UnitDerivedTesla, div(UnitDerivedWeber.UnitExponents, UCombination{{Unit: UnitMeter, Exponent: IntExponent(2)}})},
+			}
+			for _, c := range cases {
+				So(c.Derived.UnitExponents.Equal(&c.Comb), ShouldBeTrue)
+			}
+		})
+		Convey("should format in the requested unit", func() {
+			q := Q{
+				Number: Float(1500),
+				UnitExponents: UCombination{
+					{Unit: UnitGram, Exponent: IntExponent(1)},
+					{Unit: UnitMeter, Exponent: IntExponent(2)},
+					{Unit: UnitSecond, Exponent: IntExponent(-2)},
+				},
+				DerivedUnitsToUse: UDerivedList{UnitDerivedJoule},
+			}
+			num, unit := q.Format()
+			So(num.Float64(), ShouldAlmostEqual, 1.5)
+			So(unit, ShouldResemble, UnitDisplayList{{Identifier: "J", Exponent: IntExponent(1)}})
+		})
+	})
+}
+
+func TestBuiltinPrefixedUnits(t *testing.T) {
+	Convey("Deprecated prefixed unit lists", t, func() {
+		r := NewDefaultRegistry()
+		for _, list := range []UDerivedList{
+			UnitDerivedGramEng, UnitDerivedLiterEng, UnitDerivedMeterEng,
+			UnitDerivedMoleEng, UnitDerivedAmuEng, UnitDerivedMolarEng,
+		} {
+			for _, d := range list {
+				_, derived := r.Lookup(d.Identifier)
+				So(derived, ShouldNotBeNil)
+				So(*derived, ShouldResemble, d)
+			}
+		}
+	})
+}
+
+func TestBuiltinTimeUnits(t *testing.T) {
+	Convey("Time units", t, func() {
+		r := NewDefaultRegistry()
+
+		Convey("should not accept prefixes", func() {
+			_, derived := r.Lookup("kmin")
+			So(derived, ShouldBeNil)
+			_, derived = r.Lookup("h")
+			So(derived.Multiplier, ShouldEqual, 3600)
+			_, derived = r.Lookup("week")
+			So(derived.Multiplier, ShouldEqual, 7*86400)
+		})
+		Convey("should only be displayed when preferred", func() {
+			q := Q{Number: Float(7200), UnitExponents: UCombination{{Unit: UnitSecond, Exponent: IntExponent(1)}}}
+			num, units := q.FormatWith(r.FormatOptions(true, false))
+			So(num.Float64(), ShouldEqual, 7200)
+			So(units, ShouldResemble, UnitDisplayList{{Identifier: "s", Exponent: IntExponent(1)}})
+
+			q.DerivedUnitsToUse = UDerivedList{UnitDerivedHour}
+			num, units = q.FormatWith(r.FormatOptions(true, false))
+			So(num.Float64(), ShouldEqual, 2)
+			So(units, ShouldResemble, UnitDisplayList{{Identifier: "h", Exponent: IntExponent(1)}})
+		})
+	})
+}
+
+func TestBuiltinRatioUnits(t *testing.T) {
+	Convey("Ratio units", t, func() {
+		r := NewDefaultRegistry()
+
+		Convey("should not accept prefixes", func() {
+			base, derived := r.Lookup("m%")
+			So(base, ShouldBeNil)
+			So(derived, ShouldBeNil)
+			_, derived = r.Lookup("ppm")
+			So(derived.Multiplier, ShouldEqual, 1e-6)
+		})
+		Convey("should only display dimensionless quantities", func() {
+			q := Q{Number: Float(0.05), DerivedUnitsToUse: UDerivedList{UnitDerivedPercent}}
+			num, units := q.Format()
+			So(num.Float64(), ShouldAlmostEqual, 5)
+			So(units, ShouldResemble, UnitDisplayList{{Identifier: "%", Exponent: IntExponent(1)}})
+
+			q.UnitExponents = UCombination{{Unit: UnitGram, Exponent: IntExponent(1)}}
+			num, units = q.Format()
+			So(num.Float64(), ShouldAlmostEqual, 0.05)
+			So(units, ShouldResemble, UnitDisplayList{{Identifier: "g", Exponent: IntExponent(1)}})
+		})
+		Convey("% w/v should be grams per 100 ml", func() {
+			_, milligram := r.Lookup("mg")
+			_, milliliter := r.Lookup("ml")
+			q := Q{
+				Number:            Float(5 * UnitDerivedPercentWV.Multiplier),
+				UnitExponents:     UnitDerivedPercentWV.UnitExponents,
+				DerivedUnitsToUse: UDerivedList{*milligram, *milliliter},
+			}
+			num, _ := q.Format()
+			So(num.Float64(), ShouldAlmostEqual, 50)
+		})
+	})
+}
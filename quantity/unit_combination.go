@@ -26,10 +26,28 @@ func (u1 UCombination) HasOverlap(u2 UCombination) bool {
 
 func (u UCombination) Inverse() {
 	for i := range u {
-		u[i].Exponent = -u[i].Exponent
+		u[i].Exponent = u[i].Exponent.Neg()
 	}
 }
 
+// Pow raises the combination to the power of e
+func (u UCombination) Pow(e Exponent) {
+	for i := range u {
+		u[i].Exponent = u[i].Exponent.Mul(e)
+	}
+}
+
+// ExponentOf finds the exponent of a base unit in the combination, zero if it does not contain the unit
+func (u UCombination) ExponentOf(unit U) Exponent {
+	res := IntExponent(0)
+	for _, ue := range u {
+		if ue.Unit == unit {
+			res = res.Add(ue.Exponent)
+		}
+	}
+	return res
+}
+
 func (u UCombination) IsNoUnit() bool {
 	u.Simplify()
 	return len(u) == 0
@@ -42,13 +60,20 @@ func (u UCombination) Clone() UCombination {
 }
 
 // Derives tries to express comb in exponents of u
-func (u UCombination) Derive(comb UCombination) (remain UCombination, exp int) {
+//
+// The resulting exponent is truncated to a multiple of 1/n, where n is the least common
+// denominator of the exponents in comb, so it is always an integer if comb is integral.
+func (u UCombination) Derive(comb UCombination) (remain UCombination, exp Exponent) {
 
-	maxNegativeExponent := 0
-	minPositiveExponent := 0
+	var maxNegativeExponent, minPositiveExponent Exponent
 
 	comb.Simplify()
 
+	step := IntExponent(1)
+	for _, c := range comb {
+		step = NewExponent(1, step.Den()*c.Exponent.Den()/gcd(step.Den(), c.Exponent.Den()))
+	}
+
 	remain = comb.Clone()
 
 	for _, derivedUnitElem := range u {
@@ -57,42 +82,42 @@ func (u UCombination) Derive(comb UCombination) (remain UCombination, exp int) {
 		for _, gotElem := range comb {
 			if gotElem.Unit.ID == derivedUnitID {
 				found = true
-				exp := gotElem.Exponent / derivedUnitElem.Exponent
-				if exp > 0 {
-					if minPositiveExponent == 0 {
+				exp := gotElem.Exponent.Div(derivedUnitElem.Exponent).Div(step).Trunc().Mul(step)
+				if exp.Sign() > 0 {
+					if minPositiveExponent.IsZero() {
 						minPositiveExponent = exp
-					} else if exp < minPositiveExponent {
+					} else if exp.Less(minPositiveExponent) {
 						minPositiveExponent = exp
 					}
-				} else if exp < 0 {
-					if maxNegativeExponent == 0 {
+				} else if exp.Sign() < 0 {
+					if maxNegativeExponent.IsZero() {
 						maxNegativeExponent = exp
-					} else if exp > maxNegativeExponent {
+					} else if maxNegativeExponent.Less(exp) {
 						maxNegativeExponent = exp
 					}
 				} else {
-					return remain, 0
+					return remain, Exponent{}
 				}
 			}
 		}
 		if !found {
-			return remain, 0
+			return remain, Exponent{}
 		}
 	}
 
-	if minPositiveExponent == 0 {
+	if minPositiveExponent.IsZero() {
 		exp = maxNegativeExponent
-	} else if maxNegativeExponent == 0 {
+	} else if maxNegativeExponent.IsZero() {
 		exp = minPositiveExponent
 	} else {
-		return remain, 0
+		return remain, Exponent{}
 	}
 
 	for i, c := range remain {
 		unitID := c.Unit.ID
 		for _, uc := range u {
 			if uc.Unit.ID == unitID {
-				remain[i].Exponent -= uc.Exponent * exp
+				remain[i].Exponent = remain[i].Exponent.Sub(uc.Exponent.Mul(exp))
 			}
 		}
 	}
@@ -101,13 +126,13 @@ func (u UCombination) Derive(comb UCombination) (remain UCombination, exp int) {
 }
 
 func (u *UCombination) Simplify() {
-	tmp := make(map[U]int)
+	tmp := make(map[U]Exponent)
 	for _, e := range *u {
-		tmp[e.Unit] += e.Exponent
+		tmp[e.Unit] = tmp[e.Unit].Add(e.Exponent)
 	}
 	var res UCombination
 	for unit, e := range tmp {
-		if e != 0 {
+		if !e.IsZero() {
 			res = append(res, UExp{Unit: unit, Exponent: e})
 		}
 	}
@@ -155,7 +180,7 @@ func (u UCombination) Less(i int, j int) bool {
 	if u[i].Exponent == u[j].Exponent {
 		return u[i].Unit.ID > u[j].Unit.ID
 	}
-	return u[i].Exponent > u[j].Exponent
+	return u[j].Exponent.Less(u[i].Exponent)
 }
 
 // Swap swaps the elements with indexes i and j.
@@ -168,8 +193,8 @@ func (u UCombination) String() string {
 	var b bytes.Buffer
 	for i := range u {
 		fmt.Fprintf(&b, "(%s)", u[i].Unit.Identifier)
-		if u[i].Exponent != 1 {
-			fmt.Fprintf(&b, "%d", u[i].Exponent)
+		if u[i].Exponent != IntExponent(1) {
+			fmt.Fprintf(&b, "%s", u[i].Exponent)
 		}
 	}
 	return b.String()
@@ -0,0 +1,58 @@
+package quantity
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitCombination(t *testing.T) {
+	Convey("Unit combinations", t, func() {
+		Convey("square root should halve exponents", func() {
+			comb := UCombination{
+				{Unit: UnitMeter, Exponent: IntExponent(2)},
+				{Unit: UnitSecond, Exponent: IntExponent(-1)},
+			}
+			comb.Pow(NewExponent(1, 2))
+			comb.Simplify()
+			So(comb, ShouldResemble, UCombination{
+				{Unit: UnitMeter, Exponent: IntExponent(1)},
+				{Unit: UnitSecond, Exponent: NewExponent(-1, 2)},
+			})
+			So(comb.String(), ShouldEqual, "(m)(s)-1/2")
+		})
+		Convey("should derive fractional exponents of fractional combinations", func() {
+			// √(m²/s)
+			comb := UCombination{
+				{Unit: UnitMeter, Exponent: IntExponent(1)},
+				{Unit: UnitSecond, Exponent: NewExponent(-1, 2)},
+			}
+			remain, exp := UnitDerivedHertz.UnitExponents.Derive(comb)
+			So(exp, ShouldResemble, NewExponent(1, 2))
+			So(remain, ShouldResemble, UCombination{{Unit: UnitMeter, Exponent: IntExponent(1)}})
+			remain, exp = UCombination{{Unit: UnitSecond, Exponent: IntExponent(1)}}.Derive(
+				UCombination{{Unit: UnitSecond, Exponent: NewExponent(5, 6)}})
+			So(exp, ShouldResemble, NewExponent(5, 6))
+			So(remain.IsNoUnit(), ShouldBeTrue)
+		})
+		Convey("should not derive fractional exponents of integral combinations", func() {
+			comb := UCombination{{Unit: UnitMeter, Exponent: IntExponent(3)}}
+			area := UCombination{{Unit: UnitMeter, Exponent: IntExponent(2)}}
+			remain, exp := area.Derive(comb)
+			So(exp, ShouldResemble, IntExponent(1))
+			So(remain, ShouldResemble, UCombination{{Unit: UnitMeter, Exponent: IntExponent(1)}})
+		})
+		Convey("should format fractional exponents", func() {
+			q := Q{
+				Number: Float(2e-3),
+				UnitExponents: UCombination{
+					{Unit: UnitSecond, Exponent: NewExponent(-1, 2)},
+				},
+				DerivedUnitsToUse: UDerivedList{DeriveUnitWithEnginneringSymbolOnDerivedUnit("k", UnitDerivedHertz)},
+			}
+			num, unit := q.Format()
+			So(num.Float64(), ShouldAlmostEqual, 2e-3/31.6227766)
+			So(unit, ShouldResemble, UnitDisplayList{{Identifier: "kHz", Exponent: NewExponent(1, 2)}})
+		})
+	})
+}
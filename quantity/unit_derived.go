@@ -2,14 +2,146 @@ package quantity
 
 import (
 	"fmt"
+	"math/big"
 	"sort"
+	"strconv"
 )
 
 type UDerived struct {
-	Identifier    string
+	Identifier string
+	// Offset and Multiplier are the closest float64 to the exact factors of the unit,
+	// see ExactOffset and ExactMultiplier
 	Offset        float64
 	Multiplier    float64
 	UnitExponents UCombination
+
+	// Interval marks the unit as a difference on an affine scale, like Δ°C
+	Interval bool
+
+	// ExplicitOnly marks units that are only used for display when chosen explicitly,
+	// like Gy which would otherwise be used for any (m)2(s)-2
+	ExplicitOnly bool
+
+	// Prefix is the prefix the unit was derived with, if any
+	Prefix Prefix
+
+	// Log is the factor of a logarithmic unit, zero for linear units.
+	// A quantity x is Log * log10(x / Multiplier) in a logarithmic unit, see NewLogarithmicUnit.
+	Log float64
+
+	// exactMultiplier and exactOffset are the exact factors of the unit,
+	// nil if they are the decimal representation of Multiplier and Offset
+	exactMultiplier *big.Rat
+	exactOffset     *big.Rat
+}
+
+// ExactMultiplier returns the multiplier as an exact rational number.
+//
+// Unless set by WithExactFactors, this is the shortest decimal representation of Multiplier,
+// like 0.0254 for in, or nil if Multiplier is NaN or infinite.
+func (u UDerived) ExactMultiplier() *big.Rat {
+	if u.exactMultiplier != nil {
+		return new(big.Rat).Set(u.exactMultiplier)
+	}
+	return decimalRat(u.Multiplier)
+}
+
+// ExactOffset returns the offset as an exact rational number, like ExactMultiplier
+func (u UDerived) ExactOffset() *big.Rat {
+	if u.exactOffset != nil {
+		return new(big.Rat).Set(u.exactOffset)
+	}
+	return decimalRat(u.Offset)
+}
+
+// WithExactFactors returns the same unit with an exact multiplier and offset,
+// for factors without a finite decimal representation like 5/9 for degF.
+// Multiplier and Offset are set to the closest float64.
+func (u UDerived) WithExactFactors(multiplier *big.Rat, offset *big.Rat) UDerived {
+	u.exactMultiplier = new(big.Rat).Set(multiplier)
+	u.exactOffset = new(big.Rat).Set(offset)
+	u.Multiplier, _ = multiplier.Float64()
+	u.Offset, _ = offset.Float64()
+	return u
+}
+
+// ToBase converts x in the unit into its base units, as x * Multiplier + Offset.
+//
+// The conversion uses the exact factors, see convertExact, so that 20 (degC) is 68 (degF) exactly.
+func (u UDerived) ToBase(x Number) Number {
+	m, o := u.ExactMultiplier(), u.ExactOffset()
+	if m == nil || o == nil {
+		return x.Mul(Float(u.Multiplier)).Add(Float(u.Offset))
+	}
+	if res, ok := convertExact(x, func(r *big.Rat) *big.Rat {
+		return r.Mul(r, m).Add(r, o)
+	}); ok {
+		return res
+	}
+	return x.Mul(Float(u.Multiplier)).Add(Float(u.Offset))
+}
+
+// FromBase converts x in the base units of the unit into the unit, as (x - Offset) / Multiplier
+func (u UDerived) FromBase(x Number) Number {
+	m, o := u.ExactMultiplier(), u.ExactOffset()
+	if m == nil || o == nil || m.Sign() == 0 {
+		return x.Sub(Float(u.Offset)).Quo(Float(u.Multiplier))
+	}
+	if res, ok := convertExact(x, func(r *big.Rat) *big.Rat {
+		return r.Sub(r, o).Quo(r, m)
+	}); ok {
+		return res
+	}
+	return x.Sub(Float(u.Offset)).Quo(Float(u.Multiplier))
+}
+
+// ExplicitOnlyUnit returns the same unit marked as ExplicitOnly
+func (u UDerived) ExplicitOnlyUnit() UDerived {
+	u.ExplicitOnly = true
+	return u
+}
+
+// IntervalUnit returns the unit measuring differences on the scale of a unit with an offset,
+// like Δ°C for °C
+func (u UDerived) IntervalUnit() UDerived {
+	return UDerived{
+		Identifier:    "Δ" + u.Identifier,
+		Offset:        0,
+		Multiplier:    u.Multiplier,
+		UnitExponents: u.UnitExponents.Clone(),
+		Interval:      true,
+
+		exactMultiplier: u.exactMultiplier,
+	}
+}
+
+// NewUDerived creates a derived unit without offset, where one of the
+// derived unit equals multiplier times the combination of base units.
+func NewUDerived(identifier string, multiplier float64, comb UCombination) UDerived {
+	res := UDerived{
+		Identifier:    identifier,
+		Offset:        0,
+		Multiplier:    multiplier,
+		UnitExponents: comb.Clone(),
+	}
+	res.UnitExponents.Simplify()
+	return res
+}
+
+// Definition describes the unit in base units, like 1000 (g)(m)(s)-2 for N,
+// 1 (K) + 273.15 for degC, 0.01 for %, or -1 log10(x / 1 (mol)(l)-1) for pH
+func (u UDerived) Definition() string {
+	if u.IsLogarithmic() {
+		return u.logDefinition()
+	}
+	res := strconv.FormatFloat(u.Multiplier, 'g', -1, 64)
+	if !u.UnitExponents.IsNoUnit() {
+		res += " " + u.UnitExponents.Clone().String()
+	}
+	if u.Offset != 0 {
+		res += " + " + strconv.FormatFloat(u.Offset, 'g', -1, 64)
+	}
+	return res
 }
 
 func (u UDerived) Simplifies(comb UCombination) {
@@ -106,31 +238,11 @@ func EqualUDerivedLists(l1 UDerivedList, l2 UDerivedList) bool {
 }
 
 func DeriveUnitWithEngineeringSymbol(symbol string, base U) UDerived {
-	var multiplier float64
-	switch symbol {
-	case "k":
-		multiplier = 1e3
-	case "d":
-		multiplier = 1e-1
-	case "c":
-		multiplier = 1e-2
-	case "m":
-		multiplier = 1e-3
-	case "u":
-		multiplier = 1e-6
-	case "n":
-		multiplier = 1e-9
-	case "p":
-		multiplier = 1e-12
-	default:
+	prefix, ok := LookupPrefix(symbol)
+	if !ok {
 		panic("unknown engineering symbol")
 	}
-	return UDerived{
-		Identifier:    symbol + base.Identifier,
-		Offset:        0,
-		Multiplier:    multiplier,
-		UnitExponents: UCombination{UExp{Unit: base, Exponent: 1}},
-	}
+	return prefix.Apply(base)
 }
 
 func DeriveUnitWithEngineeringSymbolList(base U, symbols ...string) UDerivedList {
@@ -142,32 +254,11 @@ func DeriveUnitWithEngineeringSymbolList(base U, symbols ...string) UDerivedList
 }
 
 func DeriveUnitWithEnginneringSymbolOnDerivedUnit(symbol string, base UDerived) UDerived {
-	var multiplier float64
-	switch symbol {
-	case "k":
-		multiplier = 1e3
-	case "d":
-		multiplier = 1e-1
-	case "c":
-		multiplier = 1e-2
-	case "m":
-		multiplier = 1e-3
-	case "u":
-		multiplier = 1e-6
-	case "n":
-		multiplier = 1e-9
-	case "p":
-		multiplier = 1e-12
-	default:
+	prefix, ok := LookupPrefix(symbol)
+	if !ok {
 		panic(fmt.Sprintf("unknown engineering symbol %s", symbol))
 	}
-	return UDerived{
-		Identifier:    symbol + base.Identifier,
-		Offset:        base.Offset,
-		Multiplier:    base.Multiplier * multiplier,
-		UnitExponents: base.UnitExponents.Clone(),
-	}
-
+	return prefix.ApplyDerived(base)
 }
 
 func DeriveUnitWithEnginneringSymbolOnDerivedUnitList(base UDerived, symbols ...string) UDerivedList {
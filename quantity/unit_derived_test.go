@@ -1,7 +1,24 @@
 package quantity
 
-import "testing"
+import (
+	"math/big"
+	"testing"
 
-func TestDerivedUnits(t *testing.T) {
+	. "github.com/smartystreets/goconvey/convey"
+)
 
+func TestDerivedUnits(t *testing.T) {
+	Convey("Derived units", t, func() {
+		Convey("should convert with exact factors", func() {
+			So(UnitDerivedFahrenheit.ExactMultiplier(), ShouldResemble, big.NewRat(5, 9))
+			So(UnitDerivedFahrenheit.ToBase(NewRat(big.NewRat(1, 1))).(Rat).FractionString(), ShouldEqual, "46067/180")
+			So(UnitDerivedFahrenheit.FromBase(UnitDerivedCelsius.ToBase(Float(20))), ShouldEqual, Float(68))
+			So(UnitDerivedFahrenheitInterval.FromBase(Float(5)), ShouldEqual, Float(9))
+		})
+		Convey("should keep exact factors of prefixed units", func() {
+			u := DeriveUnitWithEnginneringSymbolOnDerivedUnit("u", UnitDerivedFarad)
+			So(u.ExactMultiplier(), ShouldResemble, big.NewRat(1, 1e9))
+			So(u.Unprefixed().ExactMultiplier(), ShouldResemble, big.NewRat(1, 1e3))
+		})
+	})
 }
@@ -0,0 +1,217 @@
+package quantity
+
+import (
+	"html"
+	"strings"
+)
+
+// typesetUnit is how a builtin unit is written when typeset
+type typesetUnit struct {
+	// symbol is the symbol in Unicode text and HTML
+	symbol string
+	// siunitx is the name of the siunitx macro for the unit, empty if there is none
+	siunitx string
+}
+
+// typesetUnits are the typeset forms of builtin units by identifier,
+// other units are written as their identifier
+var typesetUnits = map[string]typesetUnit{
+	"g":     {symbol: "g", siunitx: "gram"},
+	"l":     {symbol: "L", siunitx: "litre"},
+	"m":     {symbol: "m", siunitx: "metre"},
+	"mol":   {symbol: "mol", siunitx: "mole"},
+	"s":     {symbol: "s", siunitx: "second"},
+	"K":     {symbol: "K", siunitx: "kelvin"},
+	"A":     {symbol: "A", siunitx: "ampere"},
+	"cd":    {symbol: "cd", siunitx: "candela"},
+	"Da":    {symbol: "Da", siunitx: "dalton"},
+	"M":     {symbol: "M"},
+	"Hz":    {symbol: "Hz", siunitx: "hertz"},
+	"N":     {symbol: "N", siunitx: "newton"},
+	"Pa":    {symbol: "Pa", siunitx: "pascal"},
+	"J":     {symbol: "J", siunitx: "joule"},
+	"W":     {symbol: "W", siunitx: "watt"},
+	"C":     {symbol: "C", siunitx: "coulomb"},
+	"V":     {symbol: "V", siunitx: "volt"},
+	"F":     {symbol: "F", siunitx: "farad"},
+	"ohm":   {symbol: "Ω", siunitx: "ohm"},
+	"S":     {symbol: "S", siunitx: "siemens"},
+	"Wb":    {symbol: "Wb", siunitx: "weber"},
+	"T":     {symbol: "T", siunitx: "tesla"},
+	"H":     {symbol: "H", siunitx: "henry"},
+	"lm":    {symbol: "lm", siunitx: "lumen"},
+	"lx":    {symbol: "lx", siunitx: "lux"},
+	"Bq":    {symbol: "Bq", siunitx: "becquerel"},
+	"Gy":    {symbol: "Gy", siunitx: "gray"},
+	"Sv":    {symbol: "Sv", siunitx: "sievert"},
+	"kat":   {symbol: "kat", siunitx: "katal"},
+	"degC":  {symbol: "°C", siunitx: "degreeCelsius"},
+	"degF":  {symbol: "°F"},
+	"ΔdegC": {symbol: "Δ°C"},
+	"ΔdegF": {symbol: "Δ°F"},
+	"min":   {symbol: "min", siunitx: "minute"},
+	"h":     {symbol: "h", siunitx: "hour"},
+	"day":   {symbol: "d", siunitx: "day"},
+	"%":     {symbol: "%", siunitx: "percent"},
+}
+
+// siunitxPrefixes are the names of the siunitx macros for SI prefixes by symbol
+var siunitxPrefixes = map[string]string{
+	"Y": "yotta", "Z": "zetta", "E": "exa", "P": "peta", "T": "tera", "G": "giga", "M": "mega",
+	"k": "kilo", "h": "hecto", "da": "deca", "d": "deci", "c": "centi", "m": "milli",
+	"µ": "micro", "μ": "micro", "u": "micro",
+	"n": "nano", "p": "pico", "f": "femto", "a": "atto", "z": "zepto", "y": "yocto",
+}
+
+// typeset splits the identifier of the unit into an SI prefix and a builtin unit,
+// ok is false if it is neither a builtin unit nor a builtin unit with an SI prefix.
+//
+// Identifiers of builtin units are never split, like Pa or min.
+func (d UnitDisplay) typeset() (prefix string, unit typesetUnit, ok bool) {
+	if unit, ok = typesetUnits[d.Identifier]; ok {
+		return "", unit, true
+	}
+	for _, p := range SplitPrefix(d.Identifier) {
+		if unit, ok = typesetUnits[p.Unit]; ok {
+			return p.Prefix.Symbol, unit, true
+		}
+	}
+	return "", typesetUnit{}, false
+}
+
+// symbol is the Unicode symbol of the unit with the micro sign for micro, like µL for ul
+func (d UnitDisplay) symbol() string {
+	prefix, unit, ok := d.typeset()
+	if !ok {
+		return d.Identifier
+	}
+	if siunitxPrefixes[prefix] == "micro" {
+		prefix = "µ"
+	}
+	return prefix + unit.symbol
+}
+
+var superscripts = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+	"-", "⁻", "/", "ᐟ",
+)
+
+// Unicode formats the units with Unicode symbols and superscripts for display, like µg·mL⁻¹ or m²,
+// which can not be entered again. It is empty if there are no units.
+func (u UnitDisplayList) Unicode() string {
+	factors := make([]string, len(u))
+	for i, d := range u {
+		factors[i] = d.symbol()
+		if d.Exponent != IntExponent(1) {
+			factors[i] += superscripts.Replace(d.Exponent.String())
+		}
+	}
+	return strings.Join(factors, "·")
+}
+
+// HTML formats the units like Unicode, with exponents in <sup> elements like µg·mL<sup>−1</sup>.
+func (u UnitDisplayList) HTML() string {
+	factors := make([]string, len(u))
+	for i, d := range u {
+		factors[i] = html.EscapeString(d.symbol())
+		if d.Exponent != IntExponent(1) {
+			factors[i] += "<sup>" + strings.ReplaceAll(d.Exponent.String(), "-", "−") + "</sup>"
+		}
+	}
+	return strings.Join(factors, "·")
+}
+
+// LaTeX formats the units as the unit argument of the siunitx \SI command,
+// like \micro\gram\per\milli\litre for (ug/ml).
+//
+// If any of the units has no siunitx macro, all units are written in the literal form
+// like mg.iu^{-1} instead.
+func (u UnitDisplayList) LaTeX() string {
+	var res strings.Builder
+	for _, d := range u {
+		prefix, unit, ok := d.typeset()
+		if !ok || unit.siunitx == "" {
+			return u.latexLiteral()
+		}
+		exp := d.Exponent
+		if exp.Sign() < 0 {
+			res.WriteString(`\per`)
+			exp = exp.Neg()
+		}
+		if prefix != "" {
+			res.WriteString(`\` + siunitxPrefixes[prefix])
+		}
+		res.WriteString(`\` + unit.siunitx)
+		switch {
+		case exp == IntExponent(1):
+		case exp == IntExponent(2):
+			res.WriteString(`\squared`)
+		case exp == IntExponent(3):
+			res.WriteString(`\cubed`)
+		default:
+			res.WriteString(`\tothe{` + exp.String() + `}`)
+		}
+	}
+	return res.String()
+}
+
+var latexSpecials = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "%", `\%`, "&", `\&`, "#", `\#`, "_", `\_`,
+	"$", `\$`, "{", `\{`, "}", `\}`, "~", `\textasciitilde{}`, "^", `\textasciicircum{}`,
+)
+
+// latexLiteral formats the units in the literal form of siunitx, like mg.iu^{-1}
+func (u UnitDisplayList) latexLiteral() string {
+	factors := make([]string, len(u))
+	for i, d := range u {
+		factors[i] = latexSpecials.Replace(d.symbol())
+		if d.Exponent != IntExponent(1) {
+			factors[i] += "^{" + d.Exponent.String() + "}"
+		}
+	}
+	return strings.Join(factors, ".")
+}
+
+// Unicode formats the quantity like String, with the units formatted by UnitDisplayList.Unicode
+// like 5 µg·mL⁻¹.
+func (d QDisplay) Unicode(fraction bool) string {
+	if units := d.Units.Unicode(); units != "" {
+		return d.NumberString(fraction) + " " + units
+	}
+	return d.NumberString(fraction)
+}
+
+// HTML formats the quantity like String, with the units formatted by UnitDisplayList.HTML
+// like 5 µg·mL<sup>−1</sup>.
+func (d QDisplay) HTML(fraction bool) string {
+	num := html.EscapeString(d.NumberString(fraction))
+	if units := d.Units.HTML(); units != "" {
+		return num + " " + units
+	}
+	return num
+}
+
+// LaTeX formats the quantity with the siunitx package, like \SI{5}{\micro\gram\per\milli\litre},
+// or \num{5} if there are no units.
+//
+// Fractions are written with \frac, like \SI[parse-numbers=false]{\frac{1}{3}}{\gram}.
+func (d QDisplay) LaTeX(fraction bool) string {
+	options := ""
+	num := d.NumberString(fraction)
+	if i := strings.Index(num, "/"); i >= 0 {
+		options = "[parse-numbers=false]"
+		sign := ""
+		if strings.HasPrefix(num, "-") {
+			sign, num = "-", num[1:]
+			i--
+		}
+		num = sign + `\frac{` + num[:i] + "}{" + num[i+1:] + "}"
+	} else {
+		num = strings.Replace(num, "±", `\pm`, 1)
+	}
+	if len(d.Units) == 0 {
+		return `\num` + options + "{" + num + "}"
+	}
+	return `\SI` + options + "{" + num + "}{" + d.Units.LaTeX() + "}"
+}
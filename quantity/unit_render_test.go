@@ -0,0 +1,76 @@
+package quantity
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitRender(t *testing.T) {
+	Convey("Typeset units", t, func() {
+		cases := []struct {
+			Units   UnitDisplayList
+			Unicode string
+			HTML    string
+			LaTeX   string
+		}{
+			{nil, "", "", ""},
+			{
+				UnitDisplayList{{Identifier: "ug", Exponent: IntExponent(1)}, {Identifier: "ml", Exponent: IntExponent(-1)}},
+				"µg·mL⁻¹", "µg·mL<sup>−1</sup>", `\micro\gram\per\milli\litre`,
+			},
+			{
+				UnitDisplayList{{Identifier: "m", Exponent: IntExponent(2)}},
+				"m²", "m<sup>2</sup>", `\metre\squared`,
+			},
+			{
+				UnitDisplayList{{Identifier: "J", Exponent: IntExponent(1)}, {Identifier: "mol", Exponent: IntExponent(-1)}, {Identifier: "K", Exponent: IntExponent(-1)}},
+				"J·mol⁻¹·K⁻¹", "J·mol<sup>−1</sup>·K<sup>−1</sup>", `\joule\per\mole\per\kelvin`,
+			},
+			{
+				UnitDisplayList{{Identifier: "kHz", Exponent: NewExponent(1, 2)}, {Identifier: "s", Exponent: IntExponent(-4)}},
+				"kHz¹ᐟ²·s⁻⁴", "kHz<sup>1/2</sup>·s<sup>−4</sup>", `\kilo\hertz\tothe{1/2}\per\second\tothe{4}`,
+			},
+			{
+				UnitDisplayList{{Identifier: "ohm", Exponent: IntExponent(1)}},
+				"Ω", "Ω", `\ohm`,
+			},
+			{
+				UnitDisplayList{{Identifier: "min", Exponent: IntExponent(-1)}},
+				"min⁻¹", "min<sup>−1</sup>", `\per\minute`,
+			},
+			{
+				UnitDisplayList{{Identifier: "mg", Exponent: IntExponent(1)}, {Identifier: "iu:insulin", Exponent: IntExponent(-1)}},
+				"mg·iu:insulin⁻¹", "mg·iu:insulin<sup>−1</sup>", `mg.iu:insulin^{-1}`,
+			},
+			{
+				UnitDisplayList{{Identifier: "%w/v", Exponent: IntExponent(1)}},
+				"%w/v", "%w/v", `\%w/v`,
+			},
+			{
+				UnitDisplayList{{Identifier: "ustsp", Exponent: IntExponent(1)}},
+				"ustsp", "ustsp", `ustsp`,
+			},
+		}
+		for _, c := range cases {
+			So(c.Units.Unicode(), ShouldEqual, c.Unicode)
+			So(c.Units.HTML(), ShouldEqual, c.HTML)
+			So(c.Units.LaTeX(), ShouldEqual, c.LaTeX)
+		}
+	})
+	Convey("Typeset quantities", t, func() {
+		d := QDisplay{Number: Float(5), Units: UnitDisplayList{{Identifier: "ug", Exponent: IntExponent(1)}, {Identifier: "ml", Exponent: IntExponent(-1)}}}
+		So(d.Unicode(false), ShouldEqual, "5 µg·mL⁻¹")
+		So(d.HTML(false), ShouldEqual, "5 µg·mL<sup>−1</sup>")
+		So(d.LaTeX(false), ShouldEqual, `\SI{5}{\micro\gram\per\milli\litre}`)
+
+		d.Uncertainty = 0.2
+		So(d.LaTeX(false), ShouldEqual, `\SI{5.00\pm0.20}{\micro\gram\per\milli\litre}`)
+
+		d = QDisplay{Number: NewRat(big.NewRat(-1, 3)), Units: UnitDisplayList{{Identifier: "g", Exponent: IntExponent(1)}}}
+		So(d.LaTeX(true), ShouldEqual, `\SI[parse-numbers=false]{-\frac{1}{3}}{\gram}`)
+		So(QDisplay{Number: Float(2)}.LaTeX(false), ShouldEqual, `\num{2}`)
+		So(QDisplay{Number: Float(2)}.Unicode(false), ShouldEqual, "2")
+	})
+}
@@ -0,0 +1,74 @@
+package quantity
+
+import (
+	"bytes"
+	"embed"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed unitsets/*.json
+var unitSetFiles embed.FS
+
+// UnitSets lists the names of the builtin unit sets, sorted.
+//
+// Unit sets are definition files that are not loaded by default:
+//
+// customary has the international pound and yard and the units derived from them,
+// and US and imperial volumes with explicit names like usfl_oz and impfl_oz.
+//
+// us and imperial add the short names like fl_oz for the volumes of either system,
+// so only one of them can be loaded at a time.
+func UnitSets() []string {
+	entries, err := unitSetFiles.ReadDir("unitsets")
+	if err != nil {
+		panic(err)
+	}
+	res := make([]string, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, strings.TrimSuffix(e.Name(), path.Ext(e.Name())))
+	}
+	sort.Strings(res)
+	return res
+}
+
+// UnitSetDefinitions reads the definitions of a builtin unit set
+func UnitSetDefinitions(name string) (Definitions, error) {
+	data, err := unitSetFiles.ReadFile("unitsets/" + name + ".json")
+	if err != nil {
+		return Definitions{}, ErrUnknownUnitSet{Name: name}
+	}
+	return ParseDefinitions(bytes.NewReader(data))
+}
+
+// LoadUnitSet adds the definitions of a builtin unit set to the registry,
+// after the unit sets it requires. Unit sets are only loaded once.
+//
+// Either the whole unit set is added, or nothing if any of its definitions
+// conflicts with the registry.
+func (r *Registry) LoadUnitSet(name string) error {
+	if r.unitSets[name] {
+		return nil
+	}
+	defs, err := UnitSetDefinitions(name)
+	if err != nil {
+		return err
+	}
+	if err := r.AddDefinitions(defs); err != nil {
+		return ErrDefinition{Entry: name, Err: err}
+	}
+	r.init()
+	r.unitSets[name] = true
+	return nil
+}
+
+// LoadedUnitSets lists the names of the unit sets loaded into the registry, sorted
+func (r *Registry) LoadedUnitSets() []string {
+	res := make([]string, 0, len(r.unitSets))
+	for name := range r.unitSets {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,73 @@
+package quantity
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitSets(t *testing.T) {
+	Convey("Builtin unit sets", t, func() {
+		r := NewDefaultRegistry()
+
+		Convey("should be listed", func() {
+			So(UnitSets(), ShouldResemble, []string{"customary", "imperial", "us"})
+		})
+		Convey("should not be loaded by default", func() {
+			_, lb := r.LookupExact("lb")
+			So(lb, ShouldBeNil)
+		})
+		Convey("should define customary units exactly", func() {
+			So(r.LoadUnitSet("customary"), ShouldBeNil)
+			So(r.LoadedUnitSets(), ShouldResemble, []string{"customary"})
+
+			for identifier, multiplier := range map[string]float64{
+				"lb":       453.59237,
+				"grain":    0.06479891,
+				"in":       0.0254,
+				"mi":       1609.344,
+				"usgal":    3.785411784,
+				"usfl_oz":  0.0295735295625,
+				"impfl_oz": 0.0284130625,
+			} {
+				_, u := r.LookupExact(identifier)
+				So(u, ShouldNotBeNil)
+				So(u.Multiplier, ShouldAlmostEqual, multiplier, 1e-15)
+			}
+			_, fl := r.LookupExact("fl_oz")
+			So(fl, ShouldBeNil)
+			// customary units take no prefixes
+			_, kin := r.Lookup("kin")
+			So(kin, ShouldBeNil)
+		})
+		Convey("should load required unit sets first", func() {
+			So(r.LoadUnitSet("us"), ShouldBeNil)
+			So(r.LoadedUnitSets(), ShouldResemble, []string{"customary", "us"})
+			_, fl := r.LookupExact("fl_oz")
+			So(fl.Identifier, ShouldEqual, "usfl_oz")
+			So(r.LoadUnitSet("us"), ShouldBeNil)
+		})
+		Convey("should not load both US and imperial names", func() {
+			So(r.LoadUnitSet("imperial"), ShouldBeNil)
+			_, fl := r.LookupExact("fl_oz")
+			So(fl.Identifier, ShouldEqual, "impfl_oz")
+
+			err := r.LoadUnitSet("us")
+			So(err, ShouldNotBeNil)
+			So(r.LoadedUnitSets(), ShouldResemble, []string{"customary", "imperial"})
+			_, fl = r.LookupExact("fl_oz")
+			So(fl.Identifier, ShouldEqual, "impfl_oz")
+		})
+		Convey("should be required by definition files", func() {
+			defs := Definitions{Requires: []string{"us"}, Aliases: []Alias{{Alias: "gallon", Identifier: "usgal"}}}
+			So(r.AddDefinitions(defs), ShouldBeNil)
+			So(r.LoadedUnitSets(), ShouldResemble, []string{"customary", "us"})
+		})
+		Convey("should reject unknown unit sets", func() {
+			err := r.LoadUnitSet("metric")
+			So(errors.As(err, &ErrUnknownUnitSet{}), ShouldBeTrue)
+			So(r.LoadedUnitSets(), ShouldBeEmpty)
+		})
+	})
+}
@@ -3,9 +3,10 @@ package repl
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
-	"strconv"
+	"os"
 
 	"github.com/eternal-flame-ad/unitdc/localize"
 	"github.com/eternal-flame-ad/unitdc/quantity"
@@ -15,6 +16,20 @@ import (
 )
 
 type R struct {
+	// Fractions displays exact numbers as fractions instead of decimals
+	Fractions bool
+	// Unicode displays units with Unicode symbols and superscripts like µg·mL⁻¹,
+	// which can not be entered again
+	Unicode bool
+	// SessionFile is where units defined during the session are saved, if not empty
+	SessionFile string
+	// AutoUnits displays quantities in the simplest derived units of Registry
+	AutoUnits bool
+	// AutoPrefix displays quantities with the prefix that keeps the number in [1, 1000),
+	// unless the units were requested explicitly
+	AutoPrefix bool
+	Registry   *quantity.Registry
+
 	inputCount  uint64
 	outputCount uint64
 	Input       *bufio.Scanner
@@ -55,6 +70,52 @@ func (r *R) ReadToken() (tok syntax.Token, err error) {
 	return
 }
 
+// PrintUnits lists all units in the registry, with the definitions of derived units and aliases
+func (r *R) PrintUnits(registry *quantity.Registry) (err error) {
+	for _, u := range registry.Units() {
+		if s, ok := registry.Substance(u); ok {
+			if _, err = fmt.Fprintf(r.Output, "\t(%s) ~ %v (g)\n", u.Identifier, s.Mass); err != nil {
+				return
+			}
+			continue
+		}
+		if _, err = fmt.Fprintf(r.Output, "\t(%s)\n", u.Identifier); err != nil {
+			return
+		}
+	}
+	for _, d := range registry.DerivedUnits() {
+		if _, err = fmt.Fprintf(r.Output, "\t(%s) = %s\n", d.Identifier, d.Definition()); err != nil {
+			return
+		}
+	}
+	for _, a := range registry.Aliases() {
+		if _, err = fmt.Fprintf(r.Output, "\t(%s) = (%s)\n", a.Alias, a.Identifier); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// SaveDefinitions writes the units defined during the session to the session file
+func (r *R) SaveDefinitions(defs quantity.Definitions) error {
+	if r.SessionFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(defs, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.SessionFile, data, 0644)
+}
+
+func (r *R) formatOptions() (opts quantity.FormatOptions) {
+	if r.Registry != nil {
+		return r.Registry.FormatOptions(r.AutoUnits, r.AutoPrefix)
+	}
+	opts.AutoPrefix = r.AutoPrefix
+	return
+}
+
 func (r *R) PrintQuantity(values []quantity.Q) (err error) {
 	outputPromptHeader := fmt.Sprintf("Out(%d): ", r.outputCount)
 	_, err = fmt.Fprint(r.Output, outputPromptHeader, "\n")
@@ -63,15 +124,12 @@ func (r *R) PrintQuantity(values []quantity.Q) (err error) {
 	}
 	r.outputCount++
 	for i, value := range values {
-		num, unit := value.Format()
-		unitStr := ""
-		for _, u := range unit {
-			unitStr += fmt.Sprintf("(%s)", u.Identifier)
-			if u.Exponent != 1 {
-				unitStr += strconv.Itoa(u.Exponent) + " "
-			}
+		display := value.DisplayWith(r.formatOptions())
+		str := display.String(r.Fractions)
+		if r.Unicode {
+			str = display.Unicode(r.Fractions)
 		}
-		_, err = fmt.Fprintf(r.Output, "\t[% 3d] %f %s\n", i-len(values)+1, num, unitStr)
+		_, err = fmt.Fprintf(r.Output, "\t[% 3d] %s\n", i-len(values)+1, str)
 		if err != nil {
 			return
 		}
@@ -94,3 +152,20 @@ func (r *R) PrintError(err error) error {
 	}))
 	return outputErr
 }
+
+func (r *R) PrintWarning(warning error) error {
+	output := r.Output
+	if r.OutputErr != nil {
+		output = r.OutputErr
+	}
+	_, outputErr := fmt.Fprintln(output, localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "Repl_WarningMsg",
+			Other: "Warning: {{.Warning}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Warning": warning.Error(),
+		},
+	}))
+	return outputErr
+}
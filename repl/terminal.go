@@ -0,0 +1,29 @@
+package repl
+
+import (
+	"os"
+	"strings"
+)
+
+// TerminalSupportsUnicode reports whether f is a terminal that displays Unicode,
+// judging by the locale in LC_ALL, LC_CTYPE or LANG, or by running in Windows Terminal.
+//
+// Output redirected to a file or a pipe is never considered Unicode capable,
+// so that it can be entered again.
+func TerminalSupportsUnicode(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	if os.Getenv("WT_SESSION") != "" {
+		return true
+	}
+	// the first locale variable that is set takes precedence
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return false
+}
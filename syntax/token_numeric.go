@@ -2,10 +2,14 @@ package syntax
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 )
 
+// TokenNumeric is a number, like 1.5e-3, a fraction like 1/3, or a number with its uncertainty like 1.50±0.02.
+//
+// The results of operations like 1 0 / are written as +Inf, -Inf or NaN.
 type TokenNumeric struct {
 	Literal string
 }
@@ -14,17 +18,92 @@ func (n *TokenNumeric) String() string {
 	return n.Literal
 }
 
+// uncertaintySeparators separate the value from its uncertainty, like 1.50±0.02
+var uncertaintySeparators = []string{"±", "+/-"}
+
+// split splits the literal into the value and the uncertainty, which is empty if absent
+func (n *TokenNumeric) split() (value string, uncertainty string) {
+	for _, sep := range uncertaintySeparators {
+		if i := strings.Index(n.Literal, sep); i >= 0 {
+			return n.Literal[:i], n.Literal[i+len(sep):]
+		}
+	}
+	return n.Literal, ""
+}
+
+// Special returns the value of the literals Inf, +Inf, -Inf and NaN, which can not be represented
+// by big.Rat or big.Float. ok is false for other literals.
+func (n *TokenNumeric) Special() (f float64, ok bool) {
+	value, _ := n.split()
+	sign := 1
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+	}
+	switch strings.TrimLeft(value, "+-") {
+	case "Inf":
+		return math.Inf(sign), true
+	case "NaN":
+		return math.NaN(), true
+	}
+	return 0, false
+}
+
+// BigFloat parses the literal into f, with the precision of f
 func (n *TokenNumeric) BigFloat(f *big.Float) error {
+	value, _ := n.split()
+	if strings.ContainsRune(value, '/') {
+		r := new(big.Rat)
+		if err := n.Rat(r); err != nil {
+			return err
+		}
+		f.SetRat(r)
+		return nil
+	}
 	_, _, err := f.Parse(
-		strings.ReplaceAll(n.Literal, "_", ""), 10)
-	return err
+		strings.ReplaceAll(value, "_", ""), 10)
+	if err != nil {
+		return fmt.Errorf(
+			"could not interpret numeric literal %s: %w",
+			n.Literal,
+			err)
+	}
+	return nil
+}
+
+// Rat parses the literal exactly into r
+func (n *TokenNumeric) Rat(r *big.Rat) error {
+	value, _ := n.split()
+	if _, ok := r.SetString(strings.ReplaceAll(value, "_", "")); !ok {
+		return fmt.Errorf(
+			"could not interpret numeric literal %s",
+			n.Literal)
+	}
+	return nil
 }
 
 func (n *TokenNumeric) Float() (float64, error) {
+	if f, ok := n.Special(); ok {
+		return f, nil
+	}
 	f := big.NewFloat(0)
 	if err := n.BigFloat(f); err != nil {
+		return 0, err
+	}
+	ret, _ := f.Float64()
+	return ret, nil
+}
+
+// Uncertainty parses the uncertainty written after the value, like 1.50±0.02 or 1.50+/-0.02.
+// It returns 0 if the literal has no uncertainty.
+func (n *TokenNumeric) Uncertainty() (float64, error) {
+	_, uncertainty := n.split()
+	if uncertainty == "" {
+		return 0, nil
+	}
+	f, _, err := big.ParseFloat(strings.ReplaceAll(uncertainty, "_", ""), 10, 53, big.ToNearestEven)
+	if err != nil {
 		return 0, fmt.Errorf(
-			"could not interpret numeric literal %s: %w",
+			"could not interpret uncertainty of numeric literal %s: %w",
 			n.Literal,
 			err)
 	}
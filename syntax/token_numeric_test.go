@@ -1,6 +1,7 @@
 package syntax
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -31,6 +32,8 @@ func TestNumericToken(t *testing.T) {
 				{"-.000_1", -.0001},
 				{"1e3", 1_000},
 				{"1e-3", 0.001},
+				{"1/4", 0.25},
+				{"-1_000/8", -125},
 			}
 			for _, e := range cases {
 				tok := TokenNumeric{Literal: e.Literal}
@@ -39,5 +42,38 @@ func TestNumericToken(t *testing.T) {
 				So(res, ShouldAlmostEqual, e.Expect)
 			}
 		})
+		Convey("Should parse infinities and NaN", func() {
+			for _, literal := range []string{"Inf", "+Inf"} {
+				res, err := (&TokenNumeric{Literal: literal}).Float()
+				So(err, ShouldBeNil)
+				So(math.IsInf(res, 1), ShouldBeTrue)
+			}
+			res, err := (&TokenNumeric{Literal: "-Inf"}).Float()
+			So(err, ShouldBeNil)
+			So(math.IsInf(res, -1), ShouldBeTrue)
+			res, err = (&TokenNumeric{Literal: "NaN"}).Float()
+			So(err, ShouldBeNil)
+			So(math.IsNaN(res), ShouldBeTrue)
+		})
+		Convey("Should parse uncertainty", func() {
+			cases := []struct {
+				Literal     string
+				Expect      float64
+				Uncertainty float64
+			}{
+				{"1", 1, 0},
+				{"1.50±0.02", 1.5, 0.02},
+				{"-2+/-1e-3", -2, 0.001},
+			}
+			for _, e := range cases {
+				tok := TokenNumeric{Literal: e.Literal}
+				res, err := tok.Float()
+				So(err, ShouldBeNil)
+				So(res, ShouldAlmostEqual, e.Expect)
+				u, err := tok.Uncertainty()
+				So(err, ShouldBeNil)
+				So(u, ShouldAlmostEqual, e.Uncertainty)
+			}
+		})
 	})
 }
@@ -0,0 +1,17 @@
+package syntax
+
+import "strings"
+
+// TokenString is a double-quoted string, like "vial"
+type TokenString struct {
+	Literal string
+}
+
+func (s *TokenString) String() string {
+	return s.Literal
+}
+
+// Value is the content of the string without the quotes
+func (s *TokenString) Value() string {
+	return strings.TrimSuffix(strings.TrimPrefix(s.Literal, "\""), "\"")
+}
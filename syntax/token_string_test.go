@@ -0,0 +1,24 @@
+package syntax
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStringToken(t *testing.T) {
+	Convey("String Parsing", t, func() {
+		Convey("Should be a token", func() {
+			tok := &TokenString{}
+			var t Token
+			t = tok
+			_ = t
+		})
+		Convey("Should strip quotes", func() {
+			tok := TokenString{"\"vial\""}
+			So(tok.Value(), ShouldEqual, "vial")
+			tok = TokenString{"\"two words\""}
+			So(tok.Value(), ShouldEqual, "two words")
+		})
+	})
+}
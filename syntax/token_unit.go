@@ -19,3 +19,12 @@ func (u *TokenUnit) UnitIdentifier() (string, error) {
 		),
 	), nil
 }
+
+// UnitExpression parses the identifier as a unit expression like mg/ml, see ParseUnitExpression
+func (u *TokenUnit) UnitExpression() (UnitExpression, error) {
+	identifier, err := u.UnitIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnitExpression(identifier)
+}
@@ -0,0 +1,213 @@
+package syntax
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/eternal-flame-ad/unitdc/localize"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// UnitFactor is a unit raised to a rational power in a unit expression, like s^-2 in m/s^2
+type UnitFactor struct {
+	Identifier string
+	// Num and Den are the numerator and the positive denominator of the exponent
+	Num int
+	Den int
+}
+
+// UnitExpression is a product of units with exponents, like mg/ml or kg*m/s^2.
+//
+// The identifier 1 stands for no unit, like in 1/min.
+type UnitExpression []UnitFactor
+
+// UnitExpressionOperators are the characters that can not be part of an identifier in a unit expression
+const UnitExpressionOperators = "*·/^()"
+
+type ErrUnitExpression struct {
+	Expression string
+	// Position is the 1-based position of the offending character in runes,
+	// one past the end if the expression ended unexpectedly
+	Position int
+}
+
+func (e ErrUnitExpression) Error() string {
+	return localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{
+			ID:    "SyntaxError_UnitExpression",
+			Other: "invalid unit expression {{.Expression}} at position {{.Position}}",
+		},
+		TemplateData: map[string]interface{}{
+			"Expression": e.Expression,
+			"Position":   e.Position,
+		},
+	})
+}
+
+// ParseUnitExpression parses a unit expression.
+//
+// Units are multiplied with * or ·, and divided with /, from left to right so mg/kg/min is
+// mg kg^-1 min^-1. Exponents follow ^, either an integer like s^-2 or a fraction in parentheses
+// like Hz^(1/2), and apply on the preceding unit or parenthesized expression, like µmol/(l*min).
+func ParseUnitExpression(s string) (UnitExpression, error) {
+	p := unitExpressionParser{expr: []rune(s)}
+	res, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.expr) {
+		return nil, p.error()
+	}
+	return res, nil
+}
+
+type unitExpressionParser struct {
+	expr []rune
+	pos  int
+}
+
+func (p *unitExpressionParser) error() error {
+	return ErrUnitExpression{Expression: string(p.expr), Position: p.pos + 1}
+}
+
+func (p *unitExpressionParser) peek() rune {
+	if p.pos >= len(p.expr) {
+		return 0
+	}
+	return p.expr[p.pos]
+}
+
+// product parses terms separated by * or /
+func (p *unitExpressionParser) product() (UnitExpression, error) {
+	res, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case '*', '·':
+			p.pos++
+			t, err := p.term()
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, t...)
+		case '/':
+			p.pos++
+			t, err := p.term()
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, t.pow(-1, 1)...)
+		default:
+			return res, nil
+		}
+	}
+}
+
+// term parses a unit or a parenthesized expression, with an optional exponent
+func (p *unitExpressionParser) term() (UnitExpression, error) {
+	var res UnitExpression
+	if p.peek() == '(' {
+		p.pos++
+		var err error
+		if res, err = p.product(); err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.error()
+		}
+		p.pos++
+	} else {
+		start := p.pos
+		for p.pos < len(p.expr) && !strings.ContainsRune(UnitExpressionOperators, p.expr[p.pos]) {
+			p.pos++
+		}
+		if p.pos == start {
+			return nil, p.error()
+		}
+		res = UnitExpression{{Identifier: string(p.expr[start:p.pos]), Num: 1, Den: 1}}
+	}
+	if p.peek() != '^' {
+		return res, nil
+	}
+	p.pos++
+	num, den, err := p.exponent()
+	if err != nil {
+		return nil, err
+	}
+	return res.pow(num, den), nil
+}
+
+// exponent parses an integer like -2, or a fraction in parentheses like (1/2)
+func (p *unitExpressionParser) exponent() (num int, den int, err error) {
+	if p.peek() != '(' {
+		num, err = p.integer()
+		return num, 1, err
+	}
+	p.pos++
+	if num, err = p.integer(); err != nil {
+		return
+	}
+	den = 1
+	if p.peek() == '/' {
+		p.pos++
+		if den, err = p.integer(); err != nil {
+			return
+		}
+		if den <= 0 {
+			p.pos--
+			return 0, 0, p.error()
+		}
+	}
+	if p.peek() != ')' {
+		return 0, 0, p.error()
+	}
+	p.pos++
+	return
+}
+
+// integer parses an integer with an optional sign
+func (p *unitExpressionParser) integer() (int, error) {
+	start := p.pos
+	if r := p.peek(); r == '-' || r == '+' {
+		p.pos++
+	}
+	for p.pos < len(p.expr) && unicode.IsDigit(p.expr[p.pos]) {
+		p.pos++
+	}
+	n, err := strconv.Atoi(string(p.expr[start:p.pos]))
+	if err != nil {
+		p.pos = start
+		return 0, p.error()
+	}
+	return n, nil
+}
+
+// pow raises all factors to the power of num/den
+func (e UnitExpression) pow(num int, den int) UnitExpression {
+	res := make(UnitExpression, len(e))
+	for i, f := range e {
+		f.Num *= num
+		f.Den *= den
+		if f.Den < 0 {
+			f.Num, f.Den = -f.Num, -f.Den
+		}
+		if g := gcd(f.Num, f.Den); g > 1 {
+			f.Num, f.Den = f.Num/g, f.Den/g
+		}
+		res[i] = f
+	}
+	return res
+}
+
+func gcd(a int, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
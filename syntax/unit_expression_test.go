@@ -0,0 +1,63 @@
+package syntax
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnitExpression(t *testing.T) {
+	Convey("Unit expression parsing", t, func() {
+		Convey("Should parse correctly", func() {
+			cases := []struct {
+				Expression string
+				Expect     UnitExpression
+			}{
+				{"mg", UnitExpression{{"mg", 1, 1}}},
+				{"mg/ml", UnitExpression{{"mg", 1, 1}, {"ml", -1, 1}}},
+				{"m^2", UnitExpression{{"m", 2, 1}}},
+				{"kg*m/s^2", UnitExpression{{"kg", 1, 1}, {"m", 1, 1}, {"s", -2, 1}}},
+				{"µmol/(l*min)", UnitExpression{{"µmol", 1, 1}, {"l", -1, 1}, {"min", -1, 1}}},
+				{"mg/kg/min", UnitExpression{{"mg", 1, 1}, {"kg", -1, 1}, {"min", -1, 1}}},
+				{"µg·ml^-1", UnitExpression{{"µg", 1, 1}, {"ml", -1, 1}}},
+				{"Hz^(1/2)", UnitExpression{{"Hz", 1, 2}}},
+				{"(m/s)^(-2/4)", UnitExpression{{"m", -1, 2}, {"s", 1, 2}}},
+				{"1/min", UnitExpression{{"1", 1, 1}, {"min", -1, 1}}},
+				{"iu:vitD3/ml", UnitExpression{{"iu:vitD3", 1, 1}, {"ml", -1, 1}}},
+			}
+			for _, c := range cases {
+				res, err := ParseUnitExpression(c.Expression)
+				So(err, ShouldBeNil)
+				So(res, ShouldResemble, c.Expect)
+			}
+		})
+		Convey("Should point at errors", func() {
+			cases := []struct {
+				Expression string
+				Position   int
+			}{
+				{"", 1},
+				{"mg/", 4},
+				{"mg//ml", 4},
+				{"m^", 3},
+				{"m^x", 3},
+				{"m^(1/0)", 6},
+				{"(mg/ml", 7},
+				{"mg)", 3},
+			}
+			for _, c := range cases {
+				_, err := ParseUnitExpression(c.Expression)
+				var exprErr ErrUnitExpression
+				So(errors.As(err, &exprErr), ShouldBeTrue)
+				So(exprErr.Position, ShouldEqual, c.Position)
+			}
+		})
+		Convey("Should parse unit tokens", func() {
+			tok := TokenUnit{"(mg/ml)"}
+			res, err := tok.UnitExpression()
+			So(err, ShouldBeNil)
+			So(res, ShouldResemble, UnitExpression{{"mg", 1, 1}, {"ml", -1, 1}})
+		})
+	})
+}
@@ -13,11 +13,32 @@ import (
 )
 
 var (
-	operatorTokenRegexp = regexp.MustCompile("^[cdrbpnvf+\\-*/]$")
-	numericTokenRegexp  = regexp.MustCompile("^(\\+|-)?[0-9._]+(e(\\+|-)?[0-9_]+)?$")
-	unitTokenRegexp     = regexp.MustCompile("^\\(1|[a-zA-Z]\\w*\\)$")
+	operatorTokenRegexp = regexp.MustCompile("^([cdrbpnvf+\\-*/]|[a-z][a-z0-9]+)$")
+	numericTokenRegexp  = regexp.MustCompile("^(\\+|-)?([0-9_]+/[0-9_]+|([0-9._]+(e(\\+|-)?[0-9_]+)?|Inf|NaN)((±|\\+/-)[0-9._]+(e(\\+|-)?[0-9_]+)?)?)$")
+	unitTokenRegexp     = regexp.MustCompile("^\\(\\S+\\)$")
 )
 
+// isUnitToken checks that the literal is enclosed in a pair of matching parentheses,
+// which may contain more of them like (umol/(l*min)), but not (mg)(ml)
+func isUnitToken(literal string) bool {
+	if !unitTokenRegexp.MatchString(literal) {
+		return false
+	}
+	depth := 0
+	for i, c := range literal {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(literal)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
 func isWhiteSpace(c rune) bool {
 	return unicode.IsSpace(c)
 }
@@ -66,6 +87,9 @@ func ParseToken(r io.RuneReader) (syntax.Token, error) {
 
 	var tokenBuf bytes.Buffer
 	tokenBuf.WriteRune(nextRune)
+	if nextRune == '"' {
+		return parseString(r, &tokenBuf)
+	}
 	for {
 		nextRune, _, err = r.ReadRune()
 		if err != nil {
@@ -81,7 +105,7 @@ func ParseToken(r io.RuneReader) (syntax.Token, error) {
 	}
 
 	tokenLiteral := tokenBuf.String()
-	if unitTokenRegexp.MatchString(tokenLiteral) {
+	if isUnitToken(tokenLiteral) {
 		return &syntax.TokenUnit{Literal: tokenLiteral}, nil
 	} else if numericTokenRegexp.MatchString(tokenLiteral) {
 		return &syntax.TokenNumeric{Literal: tokenLiteral}, nil
@@ -99,3 +123,27 @@ func ParseToken(r io.RuneReader) (syntax.Token, error) {
 		},
 	}))
 }
+
+// parseString reads the rest of a double-quoted string, which may contain white space
+func parseString(r io.RuneReader, tokenBuf *bytes.Buffer) (syntax.Token, error) {
+	for {
+		nextRune, _, err := r.ReadRune()
+		if err == io.EOF {
+			return nil, errors.New(localize.Localizer().MustLocalize(&i18n.LocalizeConfig{
+				DefaultMessage: &i18n.Message{
+					ID:    "Tokenizer_ErrUnterminatedString",
+					Other: "unterminated string: {{.Token}}",
+				},
+				TemplateData: map[string]interface{}{
+					"Token": tokenBuf.String(),
+				},
+			}))
+		} else if err != nil {
+			return nil, err
+		}
+		tokenBuf.WriteRune(nextRune)
+		if nextRune == '"' {
+			return &syntax.TokenString{Literal: tokenBuf.String()}, nil
+		}
+	}
+}
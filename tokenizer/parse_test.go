@@ -39,11 +39,75 @@ func TestTokenizer(t *testing.T) {
 					&syntax.TokenOperator{Literal: "p"},
 				},
 			},
+			{
+				Source: "1 (kΩ) (°C) (1)",
+				Expect: []syntax.Token{
+					&syntax.TokenNumeric{Literal: "1"},
+					&syntax.TokenUnit{Literal: "(kΩ)"},
+					&syntax.TokenUnit{Literal: "(°C)"},
+					&syntax.TokenUnit{Literal: "(1)"},
+				},
+			},
+			{
+				Source: "2.5 (mg) \"vial\" define \"a b\"p",
+				Expect: []syntax.Token{
+					&syntax.TokenNumeric{Literal: "2.5"},
+					&syntax.TokenUnit{Literal: "(mg)"},
+					&syntax.TokenString{Literal: "\"vial\""},
+					&syntax.TokenOperator{Literal: "define"},
+					&syntax.TokenString{Literal: "\"a b\""},
+					&syntax.TokenOperator{Literal: "p"},
+				},
+			},
+			{
+				Source: "1.50±0.02 (mg) 3+/-1",
+				Expect: []syntax.Token{
+					&syntax.TokenNumeric{Literal: "1.50±0.02"},
+					&syntax.TokenUnit{Literal: "(mg)"},
+					&syntax.TokenNumeric{Literal: "3+/-1"},
+				},
+			},
+			{
+				Source: "1/3 (g) 1 3 /",
+				Expect: []syntax.Token{
+					&syntax.TokenNumeric{Literal: "1/3"},
+					&syntax.TokenUnit{Literal: "(g)"},
+					&syntax.TokenNumeric{Literal: "1"},
+					&syntax.TokenNumeric{Literal: "3"},
+					&syntax.TokenOperator{Literal: "/"},
+				},
+			},
+			{
+				Source: "+Inf -Inf NaN (g)",
+				Expect: []syntax.Token{
+					&syntax.TokenNumeric{Literal: "+Inf"},
+					&syntax.TokenNumeric{Literal: "-Inf"},
+					&syntax.TokenNumeric{Literal: "NaN"},
+					&syntax.TokenUnit{Literal: "(g)"},
+				},
+			},
+			{
+				Source: "5 (mg/ml) (umol/(l*min)) (%w/v)",
+				Expect: []syntax.Token{
+					&syntax.TokenNumeric{Literal: "5"},
+					&syntax.TokenUnit{Literal: "(mg/ml)"},
+					&syntax.TokenUnit{Literal: "(umol/(l*min))"},
+					&syntax.TokenUnit{Literal: "(%w/v)"},
+				},
+			},
 		}
 		for _, c := range cases {
 			res, err := ParseTokenUntilEOF(bytes.NewBufferString(c.Source))
 			So(err, ShouldBeNil)
 			So(res, ShouldResemble, c.Expect)
 		}
+
+		_, err := ParseTokenUntilEOF(bytes.NewBufferString("\"vial define"))
+		So(err, ShouldNotBeNil)
+
+		for _, source := range []string{"(mg)(ml)", "(mg/(ml)", "(mg))", "()", "x(mg)", "5(mg)"} {
+			_, err = ParseTokenUntilEOF(bytes.NewBufferString(source))
+			So(err, ShouldNotBeNil)
+		}
 	})
 }